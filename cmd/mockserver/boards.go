@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mockBoards serves the fixture jobs in the formats of the boards the scraper supports
+type mockBoards struct {
+	jobs     []mockJob
+	pageSize int
+}
+
+// routes returns the handler of every board
+func (b *mockBoards) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, "User-agent: *\nAllow: /\n")
+	})
+	mux.HandleFunc("/greenhouse/v1/boards/", b.greenhouse)
+	mux.HandleFunc("/lever/v0/postings/", b.lever)
+	mux.HandleFunc("/api/jobs", b.genericAPI)
+	mux.HandleFunc("/feed.xml", b.feed)
+	mux.HandleFunc("/jobs", b.htmlList)
+	mux.HandleFunc("/jobs/", b.htmlDetail)
+	return mux
+}
+
+// boardJobs returns the jobs listed on a board, optionally only those of one company
+func (b *mockBoards) boardJobs(board, company string) []mockJob {
+	var jobs []mockJob
+	for _, job := range b.jobs {
+		if job.onBoard(board) && (company == "" || job.Company == company) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// hasCompany reports whether any fixture job belongs to company, so unknown boards can 404 like the real ones
+func (b *mockBoards) hasCompany(company string) bool {
+	for _, job := range b.jobs {
+		if job.Company == company {
+			return true
+		}
+	}
+	return false
+}
+
+// page returns the 1-based page of jobs and whether a further page exists
+func (b *mockBoards) page(jobs []mockJob, page, size int) ([]mockJob, bool) {
+	if size <= 0 {
+		size = b.pageSize
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	start := (page - 1) * size
+	if start >= len(jobs) {
+		return nil, false
+	}
+	end := start + size
+	if end > len(jobs) {
+		end = len(jobs)
+	}
+	return jobs[start:end], end < len(jobs)
+}
+
+// jobURL is the HTML detail page of a job on this server. Like real boards, each board links
+// to its own URL, tagged with the board name; without a board the canonical URL is returned,
+// which the HTML list and the RSS feed share.
+func jobURL(r *http.Request, job mockJob, board string) string {
+	if board == "" {
+		return fmt.Sprintf("http://%s/jobs/%d", r.Host, job.ID)
+	}
+	return fmt.Sprintf("http://%s/jobs/%d?board=%s", r.Host, job.ID, board)
+}
+
+// greenhouse serves /greenhouse/v1/boards/{company}/jobs like the Greenhouse job board API with content=true
+func (b *mockBoards) greenhouse(w http.ResponseWriter, r *http.Request) {
+	company, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/greenhouse/v1/boards/"), "/")
+	if rest != "jobs" || !b.hasCompany(company) {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"status": 404, "error": "Job board not found"})
+		return
+	}
+
+	jobs := []map[string]interface{}{}
+	for _, job := range b.boardJobs("greenhouse", company) {
+		jobs = append(jobs, map[string]interface{}{
+			"id":              job.ID,
+			"title":           job.Title,
+			"location":        map[string]string{"name": job.Location},
+			"absolute_url":    jobURL(r, job, "greenhouse"),
+			"company_name":    job.CompanyName,
+			"first_published": job.Posted.Format("2006-01-02T15:04:05-07:00"),
+			"updated_at":      job.Posted.Format("2006-01-02T15:04:05-07:00"),
+			// Greenhouse escapes the HTML of the content once more
+			"content":     html.EscapeString(job.Description),
+			"departments": []map[string]interface{}{{"id": 1, "name": job.Department}},
+			"offices":     []map[string]interface{}{},
+			"metadata": []map[string]interface{}{
+				{"id": 1, "name": "Employment Type", "value": job.EmploymentType, "value_type": "single_select"},
+				{"id": 2, "name": "Salary Range", "value": job.Salary, "value_type": "short_text"},
+				{"id": 3, "name": "Workplace Type", "value": job.Workplace, "value_type": "single_select"},
+			},
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"jobs": jobs, "meta": map[string]int{"total": len(jobs)}})
+}
+
+// lever serves /lever/v0/postings/{company}?mode=json like the Lever postings API
+func (b *mockBoards) lever(w http.ResponseWriter, r *http.Request) {
+	company := strings.Trim(strings.TrimPrefix(r.URL.Path, "/lever/v0/postings/"), "/")
+	if !b.hasCompany(company) {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"ok": false, "error": "Document not found"})
+		return
+	}
+
+	postings := []map[string]interface{}{}
+	for _, job := range b.boardJobs("lever", company) {
+		postings = append(postings, map[string]interface{}{
+			"id":   fmt.Sprintf("%08d-0000-4000-8000-000000000000", job.ID),
+			"text": job.Title,
+			"categories": map[string]interface{}{
+				"team":         job.Department,
+				"department":   job.Department,
+				"commitment":   job.EmploymentType,
+				"location":     job.Location,
+				"allLocations": []string{job.Location},
+			},
+			"workplaceType":    job.Workplace,
+			"hostedUrl":        jobURL(r, job, "lever"),
+			"applyUrl":         jobURL(r, job, "lever") + "&apply=1",
+			"createdAt":        job.Posted.UnixMilli(),
+			"descriptionPlain": plainText(job.Description),
+		})
+	}
+	writeJSON(w, http.StatusOK, postings)
+}
+
+// genericAPI serves /api/jobs?page=N&limit=M with field names the generic "api" type reads without a mapping
+func (b *mockBoards) genericAPI(w http.ResponseWriter, r *http.Request) {
+	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	all := b.boardJobs("api", "")
+	jobs, more := b.page(all, pageNumber, limit)
+
+	items := []map[string]interface{}{}
+	for _, job := range jobs {
+		items = append(items, map[string]interface{}{
+			"id":           job.ID,
+			"title":        job.Title,
+			"company":      job.CompanyName,
+			"location":     job.Location,
+			"url":          jobURL(r, job, "api"),
+			"description":  job.Description,
+			"salary":       job.Salary,
+			"published_at": job.Posted.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"jobs": items, "total": len(all), "has_more": more})
+}
+
+// rssItem is an item of the RSS feed
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Location    string `xml:"location"`
+	Description string `xml:"description"`
+}
+
+// feed serves /feed.xml, an RSS 2.0 feed whose item titles read "Title at Company"
+func (b *mockBoards) feed(w http.ResponseWriter, r *http.Request) {
+	var items []rssItem
+	for _, job := range b.boardJobs("rss", "") {
+		items = append(items, rssItem{
+			Title:       fmt.Sprintf("%s at %s", job.Title, job.CompanyName),
+			Link:        jobURL(r, job, ""),
+			GUID:        jobURL(r, job, ""),
+			PubDate:     job.Posted.Format(http.TimeFormat),
+			Location:    job.Location,
+			Description: job.Description,
+		})
+	}
+
+	feed := struct {
+		XMLName xml.Name `xml:"rss"`
+		Version string   `xml:"version,attr"`
+		Channel struct {
+			Title string    `xml:"title"`
+			Link  string    `xml:"link"`
+			Items []rssItem `xml:"item"`
+		} `xml:"channel"`
+	}{Version: "2.0"}
+	feed.Channel.Title = "Mock remote jobs"
+	feed.Channel.Link = fmt.Sprintf("http://%s/jobs", r.Host)
+	feed.Channel.Items = items
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	fmt.Fprint(w, xml.Header)
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// listTemplate renders a page of the HTML job list; cards match the "li.job" selector
+var listTemplate = template.Must(template.New("list").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Mock remote jobs - page {{.Page}}</title></head>
+<body>
+  <ul class="jobs">
+  {{- range .Jobs}}
+    <li class="job">
+      <h3 class="title"><a href="/jobs/{{.ID}}">{{.Title}}</a></h3>
+      <span class="company">{{.CompanyName}}</span>
+      <span class="location">{{.Location}}</span>
+    </li>
+  {{- end}}
+  </ul>
+  {{- if .Next}}
+  <a class="next" href="/jobs?page={{.Next}}">Next</a>
+  {{- end}}
+</body>
+</html>
+`))
+
+// htmlList serves /jobs?page=N
+func (b *mockBoards) htmlList(w http.ResponseWriter, r *http.Request) {
+	pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if pageNumber < 1 {
+		pageNumber = 1
+	}
+	jobs, more := b.page(b.boardJobs("html", ""), pageNumber, 0)
+
+	data := struct {
+		Page int
+		Jobs []mockJob
+		Next int
+	}{Page: pageNumber, Jobs: jobs}
+	if more {
+		data.Next = pageNumber + 1
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := listTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// detailTemplate renders the page of a single job with schema.org JobPosting data
+var detailTemplate = template.Must(template.New("detail").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <title>{{.Job.Title}} at {{.Job.CompanyName}}</title>
+  <script type="application/ld+json">{{.LD}}</script>
+</head>
+<body>
+  <h1 class="job-title">{{.Job.Title}}</h1>
+  <div class="company">{{.Job.CompanyName}}</div>
+  <div class="location">{{.Job.Location}}</div>
+  {{- if .Job.Salary}}
+  <div class="salary">{{.Job.Salary}}</div>
+  {{- end}}
+  <div class="job-description">{{.Description}}</div>
+</body>
+</html>
+`))
+
+// htmlDetail serves /jobs/{id}, whichever board linked to it
+func (b *mockBoards) htmlDetail(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/jobs/"))
+	var job *mockJob
+	for i := range b.jobs {
+		if err == nil && b.jobs[i].ID == id {
+			job = &b.jobs[i]
+		}
+	}
+	if job == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	posting := map[string]interface{}{
+		"@context":           "https://schema.org",
+		"@type":              "JobPosting",
+		"identifier":         map[string]interface{}{"@type": "PropertyValue", "value": strconv.Itoa(job.ID)},
+		"title":              job.Title,
+		"description":        job.Description,
+		"datePosted":         job.Posted.Format("2006-01-02T15:04:05Z07:00"),
+		"employmentType":     strings.ToUpper(strings.ReplaceAll(job.EmploymentType, "-", "_")),
+		"hiringOrganization": map[string]interface{}{"@type": "Organization", "name": job.CompanyName},
+		"jobLocation": map[string]interface{}{
+			"@type":   "Place",
+			"address": map[string]interface{}{"@type": "PostalAddress", "addressLocality": job.Location},
+		},
+	}
+	if job.Workplace == "remote" {
+		posting["jobLocationType"] = "TELECOMMUTE"
+	}
+	if job.Salary != "" {
+		posting["baseSalary"] = job.Salary
+	}
+
+	// html/template escapes JSON placed in a script element on its own
+	data := struct {
+		Job         *mockJob
+		LD          map[string]interface{}
+		Description template.HTML
+	}{Job: job, LD: posting, Description: template.HTML(job.Description)}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := detailTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// tagRegex matches HTML tags
+var tagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// plainText strips the tags of an HTML description
+func plainText(description string) string {
+	return strings.TrimSpace(html.UnescapeString(tagRegex.ReplaceAllString(description, " ")))
+}
+
+// writeJSON writes v as an indented JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
@@ -0,0 +1,151 @@
+// Command mockserver serves fake job boards on localhost so the scraper can be demoed
+// and tested end to end without network access. The jobs come from a YAML fixture and
+// are served as Greenhouse and Lever boards, a generic JSON API, an RSS feed and
+// paginated HTML list pages with a detail page per job.
+//
+// Run it next to the scraper:
+//
+//	go run ./cmd/mockserver
+//	go run . -config cmd/mockserver/config.json
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mockJob is a job of the fixture
+type mockJob struct {
+	ID             int       `yaml:"id"`
+	Title          string    `yaml:"title"`
+	Company        string    `yaml:"company"`      // Board token, e.g. "acme"
+	CompanyName    string    `yaml:"company_name"` // Display name, e.g. "Acme Corp"
+	Location       string    `yaml:"location"`
+	Workplace      string    `yaml:"workplace"` // "remote", "hybrid" or "onsite"
+	Department     string    `yaml:"department"`
+	EmploymentType string    `yaml:"employment_type"`
+	Salary         string    `yaml:"salary"`
+	Posted         time.Time `yaml:"posted"`
+	Description    string    `yaml:"description"` // HTML
+	Boards         []string  `yaml:"boards"`      // Boards listing the job; all when empty
+}
+
+// onBoard reports whether the job is listed on a board
+func (j mockJob) onBoard(board string) bool {
+	if len(j.Boards) == 0 {
+		return true
+	}
+	for _, name := range j.Boards {
+		if name == board {
+			return true
+		}
+	}
+	return false
+}
+
+// mockFixture is the YAML fixture file
+type mockFixture struct {
+	Jobs []mockJob `yaml:"jobs"`
+}
+
+// loadFixture reads and checks a fixture file
+func loadFixture(path string) (*mockFixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture %s: %w", path, err)
+	}
+
+	var fixture mockFixture
+	if err := yaml.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+
+	ids := make(map[int]bool)
+	for i, job := range fixture.Jobs {
+		if job.ID == 0 || job.Title == "" || job.Company == "" {
+			return nil, fmt.Errorf("fixture job %d needs an id, a title and a company", i+1)
+		}
+		if ids[job.ID] {
+			return nil, fmt.Errorf("fixture job id %d is used twice", job.ID)
+		}
+		ids[job.ID] = true
+
+		if job.CompanyName == "" {
+			fixture.Jobs[i].CompanyName = job.Company
+		}
+		if job.Posted.IsZero() {
+			fixture.Jobs[i].Posted = time.Now().Add(-24 * time.Hour)
+		}
+	}
+	return &fixture, nil
+}
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8080", "address to listen on")
+	fixturePath := flag.String("fixture", "cmd/mockserver/fixture.yaml", "YAML file with the jobs to serve")
+	pageSize := flag.Int("page-size", 4, "jobs per page of the JSON API and the HTML list")
+	flag.Parse()
+
+	fixture, err := loadFixture(*fixturePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	boards := &mockBoards{jobs: fixture.Jobs, pageSize: *pageSize}
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           logRequests(boards.routes()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Ctrl-C shuts the server down after in-flight requests finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving %d mock jobs on http://%s\n", len(fixture.Jobs), *addr)
+	fmt.Printf("  Greenhouse: http://%s/greenhouse/v1/boards/{company}/jobs\n", *addr)
+	fmt.Printf("  Lever:      http://%s/lever/v0/postings/{company}?mode=json\n", *addr)
+	fmt.Printf("  JSON API:   http://%s/api/jobs?page=1\n", *addr)
+	fmt.Printf("  RSS:        http://%s/feed.xml\n", *addr)
+	fmt.Printf("  HTML:       http://%s/jobs\n", *addr)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+// logRequests prints every request with its status
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		log.Printf("%s %s -> %d", r.Method, r.URL.RequestURI(), recorder.status)
+	})
+}
+
+// statusRecorder remembers the status written to a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status and passes it on
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"job-scraper/config"
+	"job-scraper/scraper"
+)
+
+// TestScrapeMockBoards runs the shipped mock config against the mock boards, through
+// validation, every source and post-processing. Filtering and notifying are left out
+// until the filter and notifier packages are implemented.
+func TestScrapeMockBoards(t *testing.T) {
+	fixture, err := loadFixture("fixture.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer((&mockBoards{jobs: fixture.Jobs, pageSize: 4}).routes())
+	defer server.Close()
+
+	// Point the config at the test server instead of the default address
+	data, err := os.ReadFile("config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(strings.ReplaceAll(string(data), "http://127.0.0.1:8080", server.URL)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := scraper.ValidateConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := scraper.NewJobScraper(cfg.Scraper).Run(context.Background(), cfg.AllSources())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	// The RSS feed links to the HTML list's detail pages, so the jobs it shares with the list are dropped as duplicates
+	want := map[string]int{
+		"Mock HTML Board": 4,
+		"Mock Greenhouse": 3,
+		"Mock Lever":      5,
+		"Mock JSON API":   4,
+		"Mock RSS Feed":   1,
+	}
+	if len(run.Results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(run.Results), len(want))
+	}
+	for _, result := range run.Results {
+		if result.Error != nil {
+			t.Errorf("%s failed: %v", result.Site, result.Error)
+		}
+		if len(result.Jobs) != want[result.Site] {
+			t.Errorf("%s kept %d jobs, want %d", result.Site, len(result.Jobs), want[result.Site])
+		}
+	}
+	if len(run.Jobs) != 17 || run.Duplicates != 3 || run.Expired != 0 {
+		t.Errorf("run has %d jobs, %d duplicates and %d expired; want 17, 3 and 0", len(run.Jobs), run.Duplicates, run.Expired)
+	}
+}
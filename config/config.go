@@ -4,14 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 )
 
 // Config represents the main configuration for the job scraper
 type Config struct {
-	// Sites to scrape for job listings
+	// Sources lists HTML and API job sources together, each tagged with its kind
+	Sources []Source `json:"sources"`
+
+	// Sites to scrape for job listings; still read, and run after Sources
 	Sites []Site `json:"sites"`
 
-	// API sites to scrape for job listings
+	// API sites to scrape for job listings; still read, and run after Sites
 	APISites []APISite `json:"api_sites"`
 
 	// Resume keywords or skills to match against
@@ -31,25 +35,388 @@ type Config struct {
 
 	// Resume file path (optional, for parsing resume)
 	ResumePath string `json:"resume_path"`
+
+	// Scraper tunes how sites are fetched
+	Scraper ScraperConfig `json:"scraper"`
+}
+
+// ScraperConfig controls how much work a scraping run does at once
+type ScraperConfig struct {
+	Concurrency int `json:"concurrency"` // Sites, company boards and requests in flight at once (default 4)
+	PerHost     int `json:"per_host"`    // Requests in flight per host (default 2)
+
+	// RateLimit applies to every host unless a site sets its own
+	RateLimit RateLimit `json:"rate_limit"`
+
+	// Retry resends requests that failed with a network error or a retryable status
+	Retry RetryConfig `json:"retry"`
+
+	// RespectRobotsTxt skips HTML and feed URLs that the host's robots.txt disallows
+	RespectRobotsTxt bool `json:"respect_robots_txt"`
+
+	// Cache keeps responses on disk between runs
+	Cache CacheConfig `json:"cache"`
+
+	// RunTimeout stops the whole run after this long; unfinished sites report a timeout
+	RunTimeout Duration `json:"run_timeout"`
+
+	// Fixtures records responses to disk, or replays them instead of using the network
+	Fixtures FixturesConfig `json:"fixtures"`
+}
+
+// FixturesConfig configures recording and replaying HTTP fixtures, e.g. for offline tests
+type FixturesConfig struct {
+	Dir  string `json:"dir"`  // Fixture directory; fixtures are off when empty
+	Mode string `json:"mode"` // "record" saves every response, "replay" serves saved ones and sends nothing
+}
+
+// CacheConfig configures the on-disk HTTP cache
+type CacheConfig struct {
+	Dir string   `json:"dir"` // Cache directory; the cache is off when empty
+	TTL Duration `json:"ttl"` // Responses younger than this are reused without a request; older ones are revalidated
+}
+
+// RetryConfig is the retry policy shared by all requests
+type RetryConfig struct {
+	MaxAttempts   int      `json:"max_attempts"`   // Attempts per request, the first included (default 3; 1 disables retries)
+	BaseDelay     Duration `json:"base_delay"`     // Delay before the first retry, doubled for each further one (default "500ms")
+	MaxDelay      Duration `json:"max_delay"`      // Cap on a single delay, Retry-After included (default "30s")
+	RetryStatuses []int    `json:"retry_statuses"` // Default 429, 500, 502, 503 and 504
+}
+
+// RateLimit throttles the requests sent to a single host
+type RateLimit struct {
+	RequestsPerSecond float64  `json:"requests_per_second"` // 0 leaves the host unthrottled
+	Burst             int      `json:"burst"`               // Requests that may be sent back to back (default 1)
+	Jitter            Duration `json:"jitter"`              // Random extra delay of up to this long per request
+}
+
+// Duration is a time.Duration written in config as a string such as "500ms" or "2s"
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("duration must be a string such as \"500ms\": %v", err)
+	}
+
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON writes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Source kinds
+const (
+	SourceHTML = "html" // Scraped from web pages or a sitemap; configured like a Site
+	SourceAPI  = "api"  // Read from an API, ATS board or feed; configured like an APISite
+)
+
+// Source is a job source of either kind. In JSON it is a Site or APISite object with an
+// extra "kind" field; without one, entries with a selector or of type "sitemap" are HTML
+// sources, entries that look like HTML sites otherwise are rejected, and everything else
+// is an API source.
+type Source struct {
+	Kind    string
+	Site    *Site    // Set for HTML sources
+	APISite *APISite // Set for API sources
+}
+
+// HTMLSource wraps a Site as a Source
+func HTMLSource(site Site) Source {
+	return Source{Kind: SourceHTML, Site: &site}
+}
+
+// APISource wraps an APISite as a Source
+func APISource(apiSite APISite) Source {
+	return Source{Kind: SourceAPI, APISite: &apiSite}
+}
+
+// Name returns the configured name of the source
+func (s Source) Name() string {
+	switch {
+	case s.Site != nil:
+		return s.Site.Name
+	case s.APISite != nil:
+		return s.APISite.Name
+	}
+	return ""
+}
+
+// UnmarshalJSON reads the kind and decodes the rest of the object as a Site or APISite
+func (s *Source) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Kind     string `json:"kind"`
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		Selector string `json:"selector"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	kind := probe.Kind
+	if kind == "" {
+		switch {
+		case probe.Selector != "" || probe.Type == "sitemap":
+			kind = SourceHTML
+		case probe.Type == SourceHTML || hasHTMLOnlyKeys(data):
+			// Taking these for API sites would only fail later with a confusing unknown type
+			return fmt.Errorf("source %s looks like an HTML site but has no selector; set \"kind\" to %q and add a selector, or set it to %q",
+				probe.Name, SourceHTML, SourceAPI)
+		default:
+			kind = SourceAPI
+		}
+	}
+
+	switch kind {
+	case SourceHTML:
+		var site Site
+		if err := json.Unmarshal(data, &site); err != nil {
+			return fmt.Errorf("source %s: %w", probe.Name, err)
+		}
+		*s = HTMLSource(site)
+	case SourceAPI:
+		var apiSite APISite
+		if err := json.Unmarshal(data, &apiSite); err != nil {
+			return fmt.Errorf("source %s: %w", probe.Name, err)
+		}
+		*s = APISource(apiSite)
+	default:
+		return fmt.Errorf("source %s has unknown kind %q (expected %q or %q)", probe.Name, probe.Kind, SourceHTML, SourceAPI)
+	}
+	return nil
+}
+
+// htmlOnlyKeys are Site settings that API sites don't have
+var htmlOnlyKeys = []string{"selectors", "url_pattern", "state_file", "follow_links", "detail_concurrency", "detail_selectors"}
+
+// hasHTMLOnlyKeys reports whether a source object sets any of htmlOnlyKeys
+func hasHTMLOnlyKeys(data []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	for _, key := range htmlOnlyKeys {
+		if _, ok := fields[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON writes the wrapped site with its kind
+func (s Source) MarshalJSON() ([]byte, error) {
+	var site interface{} = s.Site
+	if s.Kind == SourceAPI {
+		site = s.APISite
+	}
+
+	data, err := json.Marshal(site)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = make(map[string]interface{})
+	}
+	fields["kind"] = s.Kind
+	return json.Marshal(fields)
+}
+
+// AllSources returns every configured source: Sources first, then Sites and APISites
+func (c *Config) AllSources() []Source {
+	sources := make([]Source, 0, len(c.Sources)+len(c.Sites)+len(c.APISites))
+	sources = append(sources, c.Sources...)
+	for _, site := range c.Sites {
+		sources = append(sources, HTMLSource(site))
+	}
+	for _, apiSite := range c.APISites {
+		sources = append(sources, APISource(apiSite))
+	}
+	return sources
 }
 
 // Site represents a job site to scrape
 type Site struct {
 	Name     string `json:"name"`
 	URL      string `json:"url"`
-	Type     string `json:"type"`     // "indeed", "linkedin", "glassdoor", etc.
-	Selector string `json:"selector"` // CSS selector for job listings
+	Type     string `json:"type"`     // "indeed", "linkedin", "glassdoor", etc., or "sitemap"
+	Selector string `json:"selector"` // CSS selector for job listings, or an XPath expression prefixed with "xpath:"
+
+	// Selectors overrides the built-in card selectors per field
+	Selectors FieldSelectors `json:"selectors"`
+
+	// Pagination follows the result list beyond the first page
+	Pagination HTMLPagination `json:"pagination"`
+
+	// Sitemap sources: URL points at a sitemap.xml or sitemap index
+	URLPattern string `json:"url_pattern"` // Regex posting URLs must match
+	StateFile  string `json:"state_file"`  // Remembers lastmod values so unchanged postings are skipped
+
+	// RateLimit overrides the global rate limit for the hosts this site requests
+	RateLimit *RateLimit `json:"rate_limit"`
+
+	// Timeout aborts the site after this long, e.g. "2m"; jobs found so far are kept
+	Timeout Duration `json:"timeout"`
+
+	// Detail page crawling: visit each job's URL to fill description, salary and posted date
+	FollowLinks       bool           `json:"follow_links"`
+	DetailConcurrency int            `json:"detail_concurrency"` // Parallel detail requests (default 4)
+	DetailSelectors   FieldSelectors `json:"detail_selectors"`
+}
+
+// FieldSelectors holds a selector per JobListing field. Selectors are CSS unless prefixed
+// with "xpath:", in which case they are evaluated relative to the card (or page) node.
+// Empty selectors fall back to schema.org JSON-LD data and common defaults.
+type FieldSelectors struct {
+	Title       string `json:"title"`
+	Company     string `json:"company"`
+	Location    string `json:"location"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	Salary      string `json:"salary"`
+	PostedDate  string `json:"posted_date"`
+
+	// Attrs reads a field from an attribute of the matched element instead of its text,
+	// keyed by field name, e.g. {"url": "data-href", "posted_date": "datetime"}
+	Attrs map[string]string `json:"attrs"`
+}
+
+// HTMLPagination describes how to reach the following pages of an HTML job list.
+// Set either NextSelector or URLTemplate; the site URL is always the first page.
+type HTMLPagination struct {
+	NextSelector  string `json:"next_selector"`    // Selector of the "next page" link (CSS or "xpath:"), read from its href
+	URLTemplate   string `json:"url_template"`     // e.g. "https://example.com/jobs?page={{page}}" or "...?start={{start}}"
+	Start         int    `json:"start"`            // {{page}} value of the first page (default 1)
+	Step          int    `json:"step"`             // Results per page, {{start}} grows by this much (default 10)
+	MaxPages      int    `json:"max_pages"`        // Safety cap on pages visited (default 10)
+	StopWhenNoNew bool   `json:"stop_when_no_new"` // Stop once a page yields no cards that weren't seen before
 }
 
 // APISite represents an API-based job site
 type APISite struct {
 	Name      string            `json:"name"`
 	URL       string            `json:"url"`
-	Type      string            `json:"type"`      // "api", "greenhouse_api", etc.
+	Type      string            `json:"type"`      // "api", "graphql", "feed", "greenhouse_api", "lever_api", etc.
 	Method    string            `json:"method"`    // "GET", "POST", etc.
 	Params    map[string]string `json:"params"`    // Query parameters
-	Companies []string          `json:"companies"` // For greenhouse_api type
-	BaseURL   string            `json:"base_url"`  // For greenhouse_api type
+	Companies []string          `json:"companies"` // For ATS types (greenhouse_api, lever_api, ...); career site URLs for workday
+	BaseURL   string            `json:"base_url"`  // For ATS types; defaults to the public endpoint when empty
+
+	// For the generic "api" type: where the jobs live in the response and how to read them
+	RootPath   string       `json:"root_path"` // e.g. "data.results[*]"; defaults to "jobs[*]"
+	Fields     FieldMapping `json:"fields"`
+	Pagination Pagination   `json:"pagination"`
+
+	// Request customisation for the generic "api" type. Header, param and body
+	// values may reference environment variables as ${VAR}.
+	Headers  map[string]string `json:"headers"`
+	Body     json.RawMessage   `json:"body"`      // JSON object sent as the request body
+	BodyType string            `json:"body_type"` // "json" (default) or "form"
+	Auth     AuthConfig        `json:"auth"`
+
+	// For the "graphql" type; jobs are read with RootPath and Fields like generic APIs
+	GraphQL GraphQLConfig `json:"graphql"`
+
+	// RateLimit overrides the global rate limit for the hosts this site requests
+	RateLimit *RateLimit `json:"rate_limit"`
+
+	// Timeout aborts the site after this long, e.g. "2m"; jobs found so far are kept
+	Timeout Duration `json:"timeout"`
+}
+
+// GraphQLConfig describes the query of a GraphQL job source
+type GraphQLConfig struct {
+	// Query is the query document; QueryFile loads it from disk instead
+	Query     string `json:"query"`
+	QueryFile string `json:"query_file"`
+
+	// Variables are sent with every request; string values may reference ${VAR}
+	Variables map[string]interface{} `json:"variables"`
+
+	// PageInfoPath locates the Relay pageInfo object (hasNextPage/endCursor), e.g. "data.jobs.pageInfo".
+	// Cursor pagination is disabled when it is empty.
+	PageInfoPath string `json:"page_info_path"`
+
+	// CursorVariable receives endCursor on the next request (default "after")
+	CursorVariable string `json:"cursor_variable"`
+}
+
+// AuthConfig describes how to authenticate against an API site.
+// Secrets are never stored in the config, only the names of the environment variables holding them.
+type AuthConfig struct {
+	Type string `json:"type"` // "bearer", "api_key" or "basic"; no authentication when empty
+
+	// TokenEnv names the variable holding the bearer token or API key
+	TokenEnv string `json:"token_env"`
+
+	// Name and In place an API key: header name (default "X-API-Key") or query parameter name
+	Name string `json:"name"`
+	In   string `json:"in"` // "header" (default) or "query"
+
+	// UsernameEnv and PasswordEnv name the variables holding basic auth credentials
+	UsernameEnv string `json:"username_env"`
+	PasswordEnv string `json:"password_env"`
+}
+
+// Pagination describes how a generic API site pages through its results
+type Pagination struct {
+	// Type is "page", "offset", "cursor" or "link" (RFC 5988 Link headers); empty means a single request
+	Type string `json:"type"`
+
+	// Param is the query parameter carrying the page number, offset or cursor
+	// (defaults to "page", "offset" and "cursor")
+	Param string `json:"param"`
+
+	// Start is the first page number (default 1) or offset (default 0). A pointer so that
+	// 0-based page APIs can set "start": 0.
+	Start *int `json:"start"`
+
+	// LimitParam and Limit set the page size; offsets advance by Limit when set,
+	// otherwise by the number of items returned
+	LimitParam string `json:"limit_param"`
+	Limit      int    `json:"limit"`
+
+	// CursorPath locates the next cursor in the response, e.g. "meta.next_cursor"
+	CursorPath string `json:"cursor_path"`
+
+	// MaxPages caps the number of requests (default 10); paging also stops on an empty page
+	MaxPages int `json:"max_pages"`
+
+	// In is "query" (default) to send the position as query parameters, or "body" to
+	// only expose it to the body template as {{page}}, {{offset}}, {{limit}} and {{cursor}}
+	In string `json:"in"`
+}
+
+// FieldMapping maps fields of a JSON job item onto JobListing fields.
+// Each entry is a path relative to the item (e.g. "location.name" or "tags[0]");
+// alternatives are separated with "|" and a value in single quotes is used literally.
+type FieldMapping struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Company     string `json:"company"`
+	Location    string `json:"location"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	Salary      string `json:"salary"`
+	PostedDate  string `json:"posted_date"`
+
+	// DateFormat is a Go time layout or one of "rfc3339", "unix", "unix_ms".
+	// Common formats are detected when it is empty.
+	DateFormat string `json:"date_format"`
 }
 
 // LocationConfig represents location filtering settings
@@ -115,8 +482,8 @@ func LoadConfig(configPath string) (*Config, error) {
 	// 4. Debug logging (if enabled)
 	if os.Getenv("JOB_SCRAPER_DEBUG") == "true" {
 		fmt.Printf("DEBUG: Config loaded successfully\n")
-		fmt.Printf("DEBUG: Sites: %d, Keywords: %d, Email enabled: %v\n",
-			len(cfg.Sites), len(cfg.ResumeKeywords), cfg.Email.Enabled)
+		fmt.Printf("DEBUG: Sources: %d, Keywords: %d, Email enabled: %v\n",
+			len(cfg.AllSources()), len(cfg.ResumeKeywords), cfg.Email.Enabled)
 	}
 
 	// 5. Return the config
@@ -126,20 +493,24 @@ func LoadConfig(configPath string) (*Config, error) {
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	// TODO: Implement this function
-	// 1. Check if at least one site is configured
-	if len(c.Sites) == 0 {
-		return fmt.Errorf("config.json requires at least 1 site configured")
+	// 1. Check if at least one source is configured
+	if len(c.AllSources()) == 0 {
+		return fmt.Errorf("config.json requires at least 1 source configured (sources, sites or api_sites)")
 	}
 	// 2. Check if at least one keyword is provided
 	if len(c.ResumeKeywords) == 0 {
 		return fmt.Errorf("config.json requires at least 1 resumeKeyword configured")
 	}
-	// 3. Validate email settings if enabled
+	// 3. Check the fixture mode
+	if c.Scraper.Fixtures.Dir != "" && c.Scraper.Fixtures.Mode != "record" && c.Scraper.Fixtures.Mode != "replay" {
+		return fmt.Errorf("scraper.fixtures.mode must be \"record\" or \"replay\", got %q", c.Scraper.Fixtures.Mode)
+	}
+	// 4. Validate email settings if enabled
 	if c.Email.Enabled {
 		// add validation
 	}
-	// 4. Validate location settings
-	// 5. Return error if validation fails
+	// 5. Validate location settings
+	// 6. Return error if validation fails
 
 	return nil
 }
@@ -2,52 +2,74 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"job-scraper/config"
 	"job-scraper/models"
 	"job-scraper/scraper"
 	"log"
 	"os"
+	"os/signal"
 	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 func main() {
+	configPath := flag.String("config", "config.json", "path to the config file")
+	noCache := flag.Bool("no-cache", false, "ignore the HTTP cache and download every response")
+	recordFixtures := flag.String("record-fixtures", "", "save every response as a fixture in this directory")
+	replayFixtures := flag.String("replay-fixtures", "", "serve responses from the fixtures in this directory instead of the network")
+	flag.Parse()
+
 	// Load config
-	cfg, err := config.LoadConfig("config.json")
+	cfg, err := config.LoadConfig(*configPath)
 	if err != nil {
 		log.Fatal("Failed to load config:", err)
 	}
+	if *noCache {
+		cfg.Scraper.Cache.Dir = ""
+	}
+	switch {
+	case *recordFixtures != "" && *replayFixtures != "":
+		log.Fatal("--record-fixtures and --replay-fixtures can't be combined")
+	case *recordFixtures != "":
+		cfg.Scraper.Fixtures = config.FixturesConfig{Dir: *recordFixtures, Mode: "record"}
+	case *replayFixtures != "":
+		cfg.Scraper.Fixtures = config.FixturesConfig{Dir: *replayFixtures, Mode: "replay"}
+	}
+	if err := scraper.ValidateConfig(cfg); err != nil {
+		log.Fatal("Invalid config: ", err)
+	}
 
 	// Create scraper
-	jobScraper := scraper.NewJobScraper()
-	ctx := context.Background()
+	jobScraper := scraper.NewJobScraper(cfg.Scraper)
 
-	// Scrape regular sites
-	fmt.Println("=== Scraping Regular Sites ===")
-	regularResults, err := jobScraper.ScrapeAllSites(ctx, cfg.Sites)
-	if err != nil {
-		log.Printf("Error scraping regular sites: %v", err)
+	// Ctrl-C stops the run; sites finished so far are still reported and saved
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if cfg.Scraper.RunTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Scraper.RunTimeout))
+		defer cancel()
 	}
 
-	// Scrape API sites
-	fmt.Println("\n=== Scraping API Sites ===")
-	apiResults, err := jobScraper.ScrapeAllAPISites(ctx, cfg.APISites)
+	// Scrape every source, HTML and API alike
+	fmt.Println("=== Scraping Sources ===")
+	run, err := jobScraper.Run(ctx, cfg.AllSources())
 	if err != nil {
-		log.Printf("Error scraping API sites: %v", err)
+		log.Printf("Error scraping sources: %v", err)
 	}
-
-	// Combine all results
-	allResults := append(regularResults, apiResults...)
+	allResults := run.Results
 
 	// Print summary
-	totalJobs := 0
 	for _, result := range allResults {
-		fmt.Printf("Scraped %s: Found %d jobs in %v\n", result.Site, len(result.Jobs), result.Duration)
-		totalJobs += len(result.Jobs)
+		fmt.Printf("Scraped %s: Found %d jobs in %v (%d requests, %d retries)\n", result.Site, len(result.Jobs), result.Duration, result.Attempts, result.Retries)
+		if len(result.Skipped) > 0 {
+			fmt.Printf("  Skipped %d URLs (e.g. %s: %s)\n", len(result.Skipped), result.Skipped[0].URL, result.Skipped[0].Reason)
+		}
 	}
-	fmt.Printf("\nTotal jobs found: %d\n", totalJobs)
+	fmt.Printf("\nTotal jobs found: %d in %v (%d duplicates, %d expired dropped)\n", len(run.Jobs), run.Duration, run.Duplicates, run.Expired)
 
 	// Print sample jobs from each source
 	for _, result := range allResults {
@@ -16,6 +16,14 @@ type JobListing struct {
 	PostedDate  time.Time `json:"posted_date"`
 	Source      string    `json:"source"` // which site it came from
 
+	// ValidThrough is when the posting expires, when the source provides it
+	ValidThrough time.Time `json:"valid_through"`
+
+	// ATS categories, when the source provides them
+	Department     string `json:"department,omitempty"`
+	EmploymentType string `json:"employment_type,omitempty"` // "Full-time", "Contract", etc.
+	WorkplaceType  string `json:"workplace_type,omitempty"`  // "remote", "hybrid", "onsite"
+
 	// Relevance scoring
 	RelevanceScore float64  `json:"relevance_score"`
 	MatchedSkills  []string `json:"matched_skills"`
@@ -38,6 +46,24 @@ type ScrapingResult struct {
 	Jobs     []JobListing  `json:"jobs"`
 	Error    error         `json:"error,omitempty"`
 	Duration time.Duration `json:"duration"`
+	Attempts int           `json:"attempts"` // HTTP requests sent, retries included
+	Retries  int           `json:"retries"`
+	Skipped  []SkippedURL  `json:"skipped,omitempty"`
+}
+
+// SkippedURL is a URL that was deliberately not requested
+type SkippedURL struct {
+	URL    string `json:"url"`
+	Reason string `json:"reason"` // e.g. "robots_disallowed"
+}
+
+// RunResult is the combined outcome of scraping every source of a run
+type RunResult struct {
+	Results    []ScrapingResult `json:"results"`    // One per source, in source order
+	Jobs       []JobListing     `json:"jobs"`       // The jobs of all results, in the same order
+	Duplicates int              `json:"duplicates"` // Jobs dropped because an earlier source already listed them
+	Expired    int              `json:"expired"`    // Jobs dropped because their posting had expired
+	Duration   time.Duration    `json:"duration"`
 }
 
 // JobMatch represents a job that matches the user's criteria
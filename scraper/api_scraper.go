@@ -1,12 +1,14 @@
 package scraper
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"html"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,45 +19,132 @@ import (
 // APIScraper handles API-based job scraping
 type APIScraper struct {
 	client *http.Client
+	cfg    config.ScraperConfig
 }
 
 // NewAPIScraper creates a new API scraper instance
-func NewAPIScraper() *APIScraper {
+func NewAPIScraper(cfg config.ScraperConfig) *APIScraper {
 	client := &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: newTransport(http.DefaultTransport, cfg),
 	}
 	return &APIScraper{
 		client: client,
+		cfg:    cfg,
 	}
 }
 
+func init() {
+	registerSource(sourceAdapter{
+		Type:     "api",
+		Required: []string{"url"},
+		Validate: func(apiSite config.APISite) error {
+			if err := validateExtraction(apiSite); err != nil {
+				return err
+			}
+			switch apiSite.Pagination.Type {
+			case "", "page", "offset", "link":
+			case "cursor":
+				if apiSite.Pagination.CursorPath == "" {
+					return fmt.Errorf("cursor pagination requires cursor_path")
+				}
+			default:
+				return fmt.Errorf("unknown pagination type %q", apiSite.Pagination.Type)
+			}
+			switch apiSite.BodyType {
+			case "", "json", "form":
+			default:
+				return fmt.Errorf("unknown body_type %q", apiSite.BodyType)
+			}
+			return nil
+		},
+		Fetch: (*APIScraper).scrapeGenericAPI,
+	})
+	registerSource(sourceAdapter{
+		Type:     "greenhouse_api",
+		Required: []string{"companies", "base_url"},
+		Fetch:    (*APIScraper).scrapeGreenhouseAPI,
+	})
+}
+
+// validateExtraction checks the JSON paths used to read jobs out of a response,
+// so a malformed path fails before the run instead of leaving fields empty
+func validateExtraction(apiSite config.APISite) error {
+	if _, err := parsePath(apiSite.RootPath); err != nil {
+		return fmt.Errorf("invalid root_path: %v", err)
+	}
+
+	fields := apiSite.Fields
+	paths := []struct{ key, expr string }{
+		{"fields.id", fields.ID},
+		{"fields.title", fields.Title},
+		{"fields.company", fields.Company},
+		{"fields.location", fields.Location},
+		{"fields.url", fields.URL},
+		{"fields.description", fields.Description},
+		{"fields.salary", fields.Salary},
+		{"fields.posted_date", fields.PostedDate},
+		{"pagination.cursor_path", apiSite.Pagination.CursorPath},
+		{"graphql.page_info_path", apiSite.GraphQL.PageInfoPath},
+	}
+	for _, path := range paths {
+		if err := validateFieldExpr(path.expr); err != nil {
+			return fmt.Errorf("invalid %s: %v", path.key, err)
+		}
+	}
+	return nil
+}
+
+// validateFieldExpr parses every alternative of a field expression; quoted literals are skipped
+func validateFieldExpr(expr string) error {
+	for _, alternative := range strings.Split(expr, "|") {
+		alternative = strings.TrimSpace(alternative)
+		if len(alternative) >= 2 && alternative[0] == '\'' && alternative[len(alternative)-1] == '\'' {
+			continue
+		}
+		if _, err := parsePath(alternative); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ScrapeAPISite scrapes a single API site for job listings
 func (as *APIScraper) ScrapeAPISite(ctx context.Context, apiSite config.APISite) (*models.ScrapingResult, error) {
+	ctx, cancel := withSiteTimeout(ctx, apiSite.Timeout)
+	defer cancel()
+	ctx, stats := withRequestStats(withRateLimit(ctx, apiSite.RateLimit))
+
+	result, err := as.scrapeAPISite(ctx, apiSite)
+	err = markInterrupted(ctx, result, err)
+	stats.record(result)
+	return result, err
+}
+
+// scrapeAPISite dispatches an API site to the adapter registered for its type
+func (as *APIScraper) scrapeAPISite(ctx context.Context, apiSite config.APISite) (*models.ScrapingResult, error) {
 	start := time.Now()
-	var jobs []models.JobListing
-	var err error
 
-	switch apiSite.Type {
-	case "api":
-		jobs, err = as.scrapeGenericAPI(ctx, apiSite)
-		if err != nil {
-			return &models.ScrapingResult{
-				Site:     apiSite.Name,
-				Jobs:     jobs,
-				Error:    err,
-				Duration: time.Since(start),
-			}, err
-		}
-	case "greenhouse_api":
-		jobs, err = as.scrapeGreenhouseAPI(ctx, apiSite)
-		if err != nil {
-			return &models.ScrapingResult{
-				Site:     apiSite.Name,
-				Jobs:     jobs,
-				Error:    err,
-				Duration: time.Since(start),
-			}, err
-		}
+	adapter, err := lookupSource(apiSite)
+	if err == nil {
+		err = adapter.validate(apiSite)
+	}
+	if err != nil {
+		return &models.ScrapingResult{
+			Site:     apiSite.Name,
+			Error:    err,
+			Duration: time.Since(start),
+		}, err
+	}
+
+	jobs, err := adapter.Fetch(as, ctx, apiSite)
+	if err != nil {
+		return &models.ScrapingResult{
+			Site:     apiSite.Name,
+			Jobs:     jobs,
+			Error:    err,
+			Duration: time.Since(start),
+		}, err
 	}
 
 	return &models.ScrapingResult{
@@ -65,7 +154,7 @@ func (as *APIScraper) ScrapeAPISite(ctx context.Context, apiSite config.APISite)
 	}, nil
 }
 
-// scrapeGenericAPI scrapes a generic API endpoint
+// scrapeGenericAPI scrapes a generic API endpoint, following its pagination settings
 func (as *APIScraper) scrapeGenericAPI(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
 	var jobs []models.JobListing
 
@@ -78,63 +167,97 @@ func (as *APIScraper) scrapeGenericAPI(ctx context.Context, apiSite config.APISi
 	// Add query parameters
 	query := reqURL.Query()
 	for key, value := range apiSite.Params {
-		query.Add(key, value)
+		query.Add(key, expandEnv(value))
 	}
 	reqURL.RawQuery = query.Encode()
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, apiSite.Method, reqURL.String(), nil)
-	if err != nil {
-		return jobs, err
-	}
+	pager := newPaginator(apiSite.Pagination)
+	pageURL := pager.apply(reqURL)
+	itemCount := 0
 
-	// Set headers
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
-	req.Header.Set("Accept", "application/json")
+	for pageURL != "" {
+		body, header, err := as.fetchGenericPage(ctx, apiSite, pageURL, pager.vars())
+		if err != nil {
+			return jobs, err
+		}
 
-	// Make request
-	resp, err := as.client.Do(req)
-	if err != nil {
-		return jobs, err
-	}
-	defer resp.Body.Close()
+		// Parse JSON response; the top level may be an object or an array
+		var response interface{}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return jobs, fmt.Errorf("failed to parse JSON: %v", err)
+		}
+
+		items, err := genericAPIItems(response, apiSite.RootPath)
+		if err != nil {
+			return jobs, err
+		}
+
+		// Extract jobs from response using the site's field mapping
+		jobs = append(jobs, as.extractJobsFromGenericAPI(items, apiSite, itemCount)...)
+		itemCount += len(items)
 
-	if resp.StatusCode != http.StatusOK {
-		return jobs, fmt.Errorf("API returned status %d", resp.StatusCode)
+		pageURL = pager.next(pageURL, response, header, len(items))
 	}
 
-	// Read response
-	body, err := io.ReadAll(resp.Body)
+	return jobs, nil
+}
+
+// fetchGenericPage requests a single page of a generic API site.
+// pageVars fill the placeholders of the site's body template.
+func (as *APIScraper) fetchGenericPage(ctx context.Context, apiSite config.APISite, pageURL string, pageVars map[string]interface{}) ([]byte, http.Header, error) {
+	reqBody, contentType, err := buildRequestBody(apiSite, pageVars)
 	if err != nil {
-		return jobs, err
+		return nil, nil, err
 	}
 
-	// Parse JSON response
-	var response map[string]interface{}
-	if err := json.Unmarshal(body, &response); err != nil {
-		return jobs, fmt.Errorf("failed to parse JSON: %v", err)
+	method := apiSite.Method
+	if method == "" && reqBody != nil {
+		method = "POST"
 	}
 
-	// Extract jobs from response (this will need to be customized per API)
-	jobs = as.extractJobsFromGenericAPI(response, apiSite.Name)
-
-	return jobs, nil
+	return as.sendRequest(ctx, apiRequest{
+		method:      method,
+		url:         pageURL,
+		body:        reqBody,
+		contentType: contentType,
+		headers:     apiSite.Headers,
+		auth:        apiSite.Auth,
+	})
 }
 
 // scrapeGreenhouseAPI scrapes multiple Greenhouse company boards
 func (as *APIScraper) scrapeGreenhouseAPI(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
-	var allJobs []models.JobListing
+	return as.scrapeCompanies(ctx, apiSite.Companies, func(ctx context.Context, company string) ([]models.JobListing, error) {
+		return as.scrapeSingleGreenhouseBoard(ctx, apiSite.BaseURL, company)
+	}), nil
+}
+
+// scrapeCompanies runs scrapeBoard for every company of an ATS site.
+// A failing company is logged and skipped so one bad board doesn't hide the rest.
+func (as *APIScraper) scrapeCompanies(ctx context.Context, companies []string, scrapeBoard func(ctx context.Context, company string) ([]models.JobListing, error)) []models.JobListing {
+	// Boards are scraped concurrently but collected in company order
+	boards := make([][]models.JobListing, len(companies))
 
-	for _, company := range apiSite.Companies {
-		jobs, err := as.scrapeSingleGreenhouseBoard(ctx, apiSite.BaseURL, company)
+	runPool(ctx, concurrency(as.cfg), len(companies), func(ctx context.Context, i int) {
+		jobs, err := scrapeBoard(ctx, companies[i])
 		if err != nil {
-			fmt.Printf("Error scraping %s: %v\n", company, err)
-			continue
+			// Once the site is interrupted every board fails the same way; the result reports it once
+			if ctx.Err() != nil {
+				noteInterrupted(ctx)
+			} else {
+				fmt.Printf("Error scraping %s: %v\n", companies[i], err)
+			}
+			return
 		}
+		boards[i] = jobs
+	})
+
+	var allJobs []models.JobListing
+	for _, jobs := range boards {
 		allJobs = append(allJobs, jobs...)
 	}
 
-	return allJobs, nil
+	return allJobs
 }
 
 // scrapeSingleGreenhouseBoard scrapes a single Greenhouse company board
@@ -144,64 +267,52 @@ func (as *APIScraper) scrapeSingleGreenhouseBoard(ctx context.Context, baseURL,
 	// Build API URL
 	apiURL := fmt.Sprintf("%s/%s/jobs?content=true", baseURL, company)
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
-	if err != nil {
-		return jobs, err
+	var response struct {
+		Jobs []greenhouseJob `json:"jobs"`
 	}
-
-	// Set headers
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
-	req.Header.Set("Accept", "application/json")
-
-	// Make request
-	resp, err := as.client.Do(req)
-	if err != nil {
+	if err := as.getJSON(ctx, apiURL, &response); err != nil {
 		return jobs, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return jobs, fmt.Errorf("API returned status %d for %s", resp.StatusCode, company)
-	}
+	// Convert to our job format
+	for _, job := range response.Jobs {
+		description := greenhouseContentToText(job.Content)
 
-	// Read response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return jobs, err
-	}
+		// Check if job is remote and relevant, using the full description rather than the title alone
+		if isRemoteJob(job.Title, company, job.Location.Name, description) && isRelevantRole(job.Title, description) {
+			companyName := job.CompanyName
+			if companyName == "" {
+				companyName = strings.Title(company) // Capitalize company name
+			}
 
-	// Parse Greenhouse API response
-	var response struct {
-		Jobs []struct {
-			ID       int    `json:"id"`
-			Title    string `json:"title"`
-			Location struct {
-				Name string `json:"name"`
-			} `json:"location"`
-			AbsoluteURL string `json:"absolute_url"`
-			CreatedAt   string `json:"created_at"`
-			UpdatedAt   string `json:"updated_at"`
-		} `json:"jobs"`
-	}
+			var departments []string
+			for _, department := range job.Departments {
+				departments = append(departments, department.Name)
+			}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return jobs, fmt.Errorf("failed to parse Greenhouse JSON: %v", err)
-	}
+			location := job.Location.Name
+			if location == "" {
+				var offices []string
+				for _, office := range job.Offices {
+					offices = append(offices, office.Name)
+				}
+				location = strings.Join(offices, "; ")
+			}
 
-	// Convert to our job format
-	for _, job := range response.Jobs {
-		// Check if job is remote and relevant
-		if as.isRemoteJob(job.Title, company, job.Location.Name, "") && as.isRelevantRole(job.Title, "") {
 			jobListing := models.JobListing{
-				ID:         fmt.Sprintf("greenhouse-%s-%d", company, job.ID),
-				Title:      job.Title,
-				Company:    strings.Title(company), // Capitalize company name
-				Location:   job.Location.Name,
-				URL:        job.AbsoluteURL,
-				Source:     fmt.Sprintf("Greenhouse - %s", strings.Title(company)),
-				ScrapedAt:  time.Now(),
-				PostedDate: time.Now(), // Greenhouse doesn't always provide creation date
+				ID:             fmt.Sprintf("greenhouse-%s-%d", company, job.ID),
+				Title:          job.Title,
+				Company:        companyName,
+				Location:       location,
+				URL:            job.AbsoluteURL,
+				Description:    description,
+				Salary:         job.metadataValue("salary", "compensation", "pay range"),
+				Source:         fmt.Sprintf("Greenhouse - %s", strings.Title(company)),
+				Department:     strings.Join(departments, ", "),
+				EmploymentType: job.metadataValue("employment type", "employment status", "job type"),
+				WorkplaceType:  normalizeWorkplaceType(job.metadataValue("workplace type", "location type", "remote")),
+				ScrapedAt:      time.Now(),
+				PostedDate:     job.postedDate(),
 			}
 			jobs = append(jobs, jobListing)
 		}
@@ -210,234 +321,212 @@ func (as *APIScraper) scrapeSingleGreenhouseBoard(ctx context.Context, baseURL,
 	return jobs, nil
 }
 
-// extractJobsFromGenericAPI extracts jobs from a generic API response
-func (as *APIScraper) extractJobsFromGenericAPI(response map[string]interface{}, source string) []models.JobListing {
-	var jobs []models.JobListing
+// greenhouseJob mirrors a single job of the Greenhouse job board API when requested with content=true
+type greenhouseJob struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	Location struct {
+		Name string `json:"name"`
+	} `json:"location"`
+	AbsoluteURL    string `json:"absolute_url"`
+	CompanyName    string `json:"company_name"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+	FirstPublished string `json:"first_published"`
+	Content        string `json:"content"` // HTML, itself HTML-escaped
+	Departments    []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"departments"`
+	Offices []struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name"`
+		Location string `json:"location"`
+	} `json:"offices"`
+	Metadata []struct {
+		ID        int             `json:"id"`
+		Name      string          `json:"name"`
+		Value     json.RawMessage `json:"value"` // string, number, list or object depending on value_type
+		ValueType string          `json:"value_type"`
+	} `json:"metadata"`
+}
 
-	// This is a generic parser - you'll need to customize based on the specific API
-	// For now, we'll look for common patterns
-	if jobsData, ok := response["jobs"].([]interface{}); ok {
-		for _, jobData := range jobsData {
-			if jobMap, ok := jobData.(map[string]interface{}); ok {
-				title := as.getStringFromMap(jobMap, "title", "name", "position")
-				company := as.getStringFromMap(jobMap, "company", "employer", "organization")
-				location := as.getStringFromMap(jobMap, "location", "city", "place")
-				jobURL := as.getStringFromMap(jobMap, "url", "link", "absolute_url")
-
-				if title != "" && as.isRemoteJob(title, company, location, "") && as.isRelevantRole(title, "") {
-					job := models.JobListing{
-						ID:         fmt.Sprintf("%s-%d", source, len(jobs)+1),
-						Title:      title,
-						Company:    company,
-						Location:   location,
-						URL:        jobURL,
-						Source:     source,
-						ScrapedAt:  time.Now(),
-						PostedDate: time.Now(),
-					}
-					jobs = append(jobs, job)
-				}
-			}
+// postedDate returns the first publication date, falling back to updated_at and finally now
+func (job greenhouseJob) postedDate() time.Time {
+	for _, value := range []string{job.FirstPublished, job.CreatedAt, job.UpdatedAt} {
+		if posted, err := time.Parse(time.RFC3339, value); err == nil {
+			return posted
 		}
 	}
-
-	return jobs
+	return time.Now()
 }
 
-// getStringFromMap safely extracts a string value from a map, trying multiple keys
-func (as *APIScraper) getStringFromMap(m map[string]interface{}, keys ...string) string {
-	for _, key := range keys {
-		if value, ok := m[key].(string); ok && value != "" {
-			return value
+// metadataValue returns the first custom field whose name contains one of the given names
+func (job greenhouseJob) metadataValue(names ...string) string {
+	for _, name := range names {
+		for _, field := range job.Metadata {
+			if !strings.Contains(strings.ToLower(field.Name), name) {
+				continue
+			}
+			if value := metadataValueToString(field.Value); value != "" {
+				return value
+			}
 		}
 	}
 	return ""
 }
 
-// isRemoteJob checks if a job is remote based on various indicators
-func (as *APIScraper) isRemoteJob(title, company, location, fullText string) bool {
-	// Convert to lowercase for case-insensitive matching
-	titleLower := strings.ToLower(title)
-	locationLower := strings.ToLower(location)
-	fullTextLower := strings.ToLower(fullText)
-
-	// Remote job indicators
-	remoteKeywords := []string{
-		"remote",
-		"work from home",
-		"wfh",
-		"virtual",
-		"distributed",
-		"telecommute",
-		"flexible location",
-		"anywhere",
-		"global",
-		"worldwide",
-		"us-remote",
-		"remote in",
-		"remote us",
-		"remote canada",
+// metadataValueToString flattens a Greenhouse metadata value into display text
+func metadataValueToString(raw json.RawMessage) string {
+	var value interface{}
+	if len(raw) == 0 || json.Unmarshal(raw, &value) != nil {
+		return ""
 	}
 
-	// Check if any remote keywords appear in title, location, or full text
-	for _, keyword := range remoteKeywords {
-		if strings.Contains(titleLower, keyword) ||
-			strings.Contains(locationLower, keyword) ||
-			strings.Contains(fullTextLower, keyword) {
-			return true
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				parts = append(parts, s)
+			}
 		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		// Currency range fields look like {"min_value": "100000", "max_value": "150000", "unit": "USD"}
+		min, _ := v["min_value"].(string)
+		max, _ := v["max_value"].(string)
+		unit, _ := v["unit"].(string)
+		return joinNonEmpty(" ", unit, joinNonEmpty(" - ", min, max))
+	default:
+		return ""
+	}
+}
+
+// greenhouseContentToText converts the escaped HTML content of a Greenhouse job into plain text
+func greenhouseContentToText(content string) string {
+	return htmlToText(html.UnescapeString(content))
+}
+
+// getJSON performs a GET request against an ATS endpoint and decodes the JSON body into v
+func (as *APIScraper) getJSON(ctx context.Context, apiURL string, v interface{}) error {
+	return as.doJSON(ctx, "GET", apiURL, nil, v)
+}
+
+// postJSON sends payload as a JSON POST body and decodes the JSON response into v
+func (as *APIScraper) postJSON(ctx context.Context, apiURL string, payload, v interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request body: %v", err)
 	}
+	return as.doJSON(ctx, "POST", apiURL, data, v)
+}
 
-	// Check for common non-remote indicators (if found, likely not remote)
-	nonRemoteKeywords := []string{
-		"on-site",
-		"onsite",
-		"in-person",
-		"office",
-		"headquarters",
-		"relocation required",
-		"must be local",
+// doJSON performs a request against a JSON endpoint and decodes the response body into v
+func (as *APIScraper) doJSON(ctx context.Context, method, apiURL string, payload []byte, v interface{}) error {
+	req := apiRequest{method: method, url: apiURL}
+	if payload != nil {
+		req.body = bytes.NewReader(payload)
+		req.contentType = "application/json"
 	}
 
-	for _, keyword := range nonRemoteKeywords {
-		if strings.Contains(titleLower, keyword) ||
-			strings.Contains(locationLower, keyword) ||
-			strings.Contains(fullTextLower, keyword) {
-			return false
-		}
+	data, _, err := as.sendRequest(ctx, req)
+	if err != nil {
+		return err
 	}
 
-	// If location is empty, might be remote
-	if location == "" {
-		return true
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse JSON from %s: %v", apiURL, err)
 	}
 
-	return false
+	return nil
+}
+
+// defaultFieldMapping is used for generic API sites that don't declare their own fields
+var defaultFieldMapping = config.FieldMapping{
+	Title:       "title|name|position",
+	Company:     "company|employer|organization",
+	Location:    "location|city|place",
+	URL:         "url|link|absolute_url",
+	Description: "description|summary",
+	Salary:      "salary",
+	PostedDate:  "posted_date|date_posted|published_at|created_at",
 }
 
-// isRelevantRole checks if a job title/description matches relevant engineering roles
-func (as *APIScraper) isRelevantRole(title, fullText string) bool {
-	// Convert to lowercase for case-insensitive matching
-	titleLower := strings.ToLower(title)
-	fullTextLower := strings.ToLower(fullText)
-
-	// Relevant role keywords
-	relevantKeywords := []string{
-		"system engineer",
-		"systems engineer",
-		"devops",
-		"dev ops",
-		"cloud engineer",
-		"sre",
-		"site reliability engineer",
-		"platform engineer",
-		"infrastructure engineer",
-		"reliability engineer",
-		"automation engineer",
-		"build engineer",
-		"release engineer",
-		"deployment engineer",
-		"kubernetes engineer",
-		"container engineer",
-		"aws engineer",
-		"azure engineer",
-		"gcp engineer",
-		"google cloud engineer",
-		"terraform engineer",
-		"ansible engineer",
-		"jenkins engineer",
-		"ci/cd engineer",
-		"monitoring engineer",
-		"observability engineer",
-		"security engineer",
-		"compliance engineer",
-		"network engineer",
-		"linux engineer",
-		"unix engineer",
-		"operations engineer",
-		"ops engineer",
-		"production engineer",
-		"backend engineer",
-		"api engineer",
-		"microservices engineer",
-		"distributed systems engineer",
-		"scalability engineer",
-		"performance engineer",
-		"data engineer",
-		"ml engineer",
-		"machine learning engineer",
-		"ai engineer",
-		"artificial intelligence engineer",
+// genericAPIItems returns the job items found under rootPath, defaulting to "jobs[*]"
+func genericAPIItems(response interface{}, rootPath string) ([]interface{}, error) {
+	if rootPath == "" {
+		rootPath = "jobs[*]"
 	}
 
-	// Check if any relevant keywords appear in title or full text
-	for _, keyword := range relevantKeywords {
-		if strings.Contains(titleLower, keyword) ||
-			strings.Contains(fullTextLower, keyword) {
-			return true
-		}
+	items, err := evalPath(response, rootPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root_path: %v", err)
 	}
+	return items, nil
+}
 
-	// Check for specific technology keywords that indicate relevant roles
-	techKeywords := []string{
-		"kubernetes",
-		"docker",
-		"terraform",
-		"ansible",
-		"puppet",
-		"chef",
-		"jenkins",
-		"gitlab ci",
-		"github actions",
-		"aws",
-		"azure",
-		"gcp",
-		"google cloud",
-		"amazon web services",
-		"microservices",
-		"containerization",
-		"orchestration",
-		"monitoring",
-		"observability",
-		"prometheus",
-		"grafana",
-		"elk stack",
-		"elasticsearch",
-		"splunk",
-		"datadog",
-		"new relic",
-		"pagerduty",
-		"incident response",
-		"disaster recovery",
-		"high availability",
-		"load balancing",
-		"auto scaling",
-		"infrastructure as code",
-		"configuration management",
-		"linux",
-		"unix",
-		"bash",
-		"shell scripting",
-		"python",
-		"golang",
-		"go",
-		"ruby",
-		"powershell",
-		"networking",
-		"security",
-		"compliance",
-		"automation",
+// extractJobsFromGenericAPI converts generic API items into jobs using the site's field mapping.
+// itemOffset is the number of items seen on earlier pages and keeps generated IDs unique.
+func (as *APIScraper) extractJobsFromGenericAPI(items []interface{}, apiSite config.APISite, itemOffset int) []models.JobListing {
+	var jobs []models.JobListing
+
+	fields := mergeFieldMapping(apiSite.Fields, defaultFieldMapping)
+
+	for i, item := range items {
+		title := lookupString(item, fields.Title)
+		company := lookupString(item, fields.Company)
+		location := lookupString(item, fields.Location)
+		description := htmlToText(lookupString(item, fields.Description))
+
+		if title != "" && isRemoteJob(title, company, location, description) && isRelevantRole(title, description) {
+			job := models.JobListing{
+				ID:          fmt.Sprintf("%s-%d", apiSite.Name, itemOffset+i+1),
+				Title:       title,
+				Company:     company,
+				Location:    location,
+				URL:         lookupString(item, fields.URL),
+				Description: description,
+				Salary:      lookupString(item, fields.Salary),
+				Source:      apiSite.Name,
+				ScrapedAt:   time.Now(),
+				PostedDate:  time.Now(),
+			}
+			if id := lookupString(item, fields.ID); id != "" {
+				job.ID = fmt.Sprintf("%s-%s", apiSite.Name, id)
+			}
+			if posted, ok := parseDateValue(lookupValue(item, fields.PostedDate), fields.DateFormat); ok {
+				job.PostedDate = posted
+			}
+			jobs = append(jobs, job)
+		}
 	}
 
-	// Count how many tech keywords appear
-	techCount := 0
-	for _, keyword := range techKeywords {
-		if strings.Contains(titleLower, keyword) ||
-			strings.Contains(fullTextLower, keyword) {
-			techCount++
+	return jobs
+}
+
+// mergeFieldMapping fills the fields left empty in mapping from defaults
+func mergeFieldMapping(mapping, defaults config.FieldMapping) config.FieldMapping {
+	pick := func(value, fallback string) string {
+		if value != "" {
+			return value
 		}
+		return fallback
 	}
 
-	// If we find 2 or more tech keywords, it's likely a relevant role
-	return techCount >= 2
+	return config.FieldMapping{
+		ID:          pick(mapping.ID, defaults.ID),
+		Title:       pick(mapping.Title, defaults.Title),
+		Company:     pick(mapping.Company, defaults.Company),
+		Location:    pick(mapping.Location, defaults.Location),
+		URL:         pick(mapping.URL, defaults.URL),
+		Description: pick(mapping.Description, defaults.Description),
+		Salary:      pick(mapping.Salary, defaults.Salary),
+		PostedDate:  pick(mapping.PostedDate, defaults.PostedDate),
+		DateFormat:  pick(mapping.DateFormat, defaults.DateFormat),
+	}
 }
@@ -0,0 +1,102 @@
+package scraper
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"job-scraper/config"
+)
+
+func TestScrapeGenericAPI(t *testing.T) {
+	as := newReplayScraper(t, "generic_api", map[string]string{
+		"/api/jobs?category=devops&limit=2&page=1": "generic_api/page1.json",
+		"/api/jobs?category=devops&limit=2&page=2": "generic_api/page2.json",
+	}).apiScraper
+
+	jobs, err := as.scrapeGenericAPI(context.Background(), config.APISite{
+		Name:     "Remote API",
+		URL:      fixtureHost + "/api/jobs",
+		Type:     "api",
+		Params:   map[string]string{"category": "devops"},
+		RootPath: "jobs[*]",
+		Fields: config.FieldMapping{
+			ID:          "id",
+			Title:       "title",
+			Company:     "company_name",
+			Location:    "candidate_required_location",
+			URL:         "url",
+			Description: "description",
+			PostedDate:  "publication_date",
+		},
+		Pagination: config.Pagination{Type: "page", LimitParam: "limit", Limit: 2, MaxPages: 5},
+	})
+	if err != nil {
+		t.Fatalf("scrapeGenericAPI: %v", err)
+	}
+
+	// The sales role on page 1 isn't relevant; page 2 is short, so no third page is requested
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2: %+v", len(jobs), jobs)
+	}
+
+	first := jobs[0]
+	if first.ID != "Remote API-101" || first.Title != "DevOps Engineer" || first.Company != "Acme" || first.Location != "Worldwide" {
+		t.Errorf("first job = %+v", first)
+	}
+	if first.URL != "https://jobs.example.com/devops/devops-engineer-101" {
+		t.Errorf("first job URL = %q", first.URL)
+	}
+	if strings.Contains(first.Description, "<") || !strings.Contains(first.Description, "Terraform and Kubernetes") {
+		t.Errorf("description should be plain text, got %q", first.Description)
+	}
+	if want := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC); !first.PostedDate.Equal(want) {
+		t.Errorf("posted date = %v, want %v", first.PostedDate, want)
+	}
+
+	if second := jobs[1]; second.ID != "Remote API-103" || second.Title != "Site Reliability Engineer" {
+		t.Errorf("second job = %+v", second)
+	}
+}
+
+func TestScrapeSingleGreenhouseBoard(t *testing.T) {
+	as := newReplayScraper(t, "greenhouse", map[string]string{
+		"/v1/boards/acme/jobs?content=true": "greenhouse/acme.json",
+	}).apiScraper
+	baseURL := fixtureHost + "/v1/boards"
+
+	jobs, err := as.scrapeSingleGreenhouseBoard(context.Background(), baseURL, "acme")
+	if err != nil {
+		t.Fatalf("scrapeSingleGreenhouseBoard: %v", err)
+	}
+
+	// The office coordinator is neither remote nor an engineering role
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2: %+v", len(jobs), jobs)
+	}
+
+	platform := jobs[0]
+	if platform.ID != "greenhouse-acme-4001" || platform.Title != "Senior Platform Engineer" || platform.Company != "Acme Corp" {
+		t.Errorf("first job = %+v", platform)
+	}
+	if platform.Salary != "$150k - $180k" || platform.EmploymentType != "Full-time" || platform.Department != "Infrastructure" {
+		t.Errorf("metadata not mapped: salary %q, employment type %q, department %q", platform.Salary, platform.EmploymentType, platform.Department)
+	}
+	if !strings.Contains(platform.Description, "Kubernetes") || strings.Contains(platform.Description, "&lt;") {
+		t.Errorf("content should be unescaped text, got %q", platform.Description)
+	}
+	if want := time.Date(2026, 9, 15, 16, 0, 0, 0, time.UTC); !platform.PostedDate.Equal(want) {
+		t.Errorf("posted date = %v, want %v", platform.PostedDate, want)
+	}
+
+	// Without a location the offices are used, and without company_name the board token
+	backend := jobs[1]
+	if backend.Location != "Remote" || backend.Company != "Acme" {
+		t.Errorf("second job location %q, company %q; want Remote and Acme", backend.Location, backend.Company)
+	}
+
+	if _, err := as.scrapeSingleGreenhouseBoard(context.Background(), baseURL, "missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("missing board: err = %v, want a 404 error", err)
+	}
+}
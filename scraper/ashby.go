@@ -0,0 +1,167 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// defaultAshbyBaseURL is Ashby's public job board API
+const defaultAshbyBaseURL = "https://api.ashbyhq.com/posting-api/job-board"
+
+// ashbyJob mirrors a single job returned by the Ashby job board API
+type ashbyJob struct {
+	ID                 string `json:"id"`
+	Title              string `json:"title"`
+	Department         string `json:"department"`
+	Team               string `json:"team"`
+	EmploymentType     string `json:"employmentType"` // "FullTime", "PartTime", "Intern", "Contract", "Temporary"
+	Location           string `json:"location"`
+	SecondaryLocations []struct {
+		Location string `json:"location"`
+	} `json:"secondaryLocations"`
+	PublishedAt      string `json:"publishedAt"`
+	IsListed         bool   `json:"isListed"`
+	IsRemote         bool   `json:"isRemote"`
+	WorkplaceType    string `json:"workplaceType"`
+	JobURL           string `json:"jobUrl"`
+	ApplyURL         string `json:"applyUrl"`
+	DescriptionPlain string `json:"descriptionPlain"`
+	Compensation     *struct {
+		CompensationTierSummary             string `json:"compensationTierSummary"`
+		ScrapeableCompensationSalarySummary string `json:"scrapeableCompensationSalarySummary"`
+		CompensationTiers                   []struct {
+			Title       string `json:"title"`
+			TierSummary string `json:"tierSummary"`
+		} `json:"compensationTiers"`
+	} `json:"compensation"`
+}
+
+func init() {
+	registerSource(sourceAdapter{
+		Type:     "ashby_api",
+		Required: []string{"companies"},
+		Fetch:    (*APIScraper).scrapeAshbyAPI,
+	})
+}
+
+// scrapeAshbyAPI scrapes multiple Ashby company job boards
+func (as *APIScraper) scrapeAshbyAPI(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
+	baseURL := apiSite.BaseURL
+	if baseURL == "" {
+		baseURL = defaultAshbyBaseURL
+	}
+
+	return as.scrapeCompanies(ctx, apiSite.Companies, func(ctx context.Context, company string) ([]models.JobListing, error) {
+		return as.scrapeSingleAshbyBoard(ctx, baseURL, company)
+	}), nil
+}
+
+// scrapeSingleAshbyBoard scrapes a single Ashby company job board
+func (as *APIScraper) scrapeSingleAshbyBoard(ctx context.Context, baseURL, company string) ([]models.JobListing, error) {
+	var jobs []models.JobListing
+
+	apiURL := fmt.Sprintf("%s/%s?includeCompensation=true", strings.TrimRight(baseURL, "/"), url.PathEscape(company))
+
+	var response struct {
+		Jobs []ashbyJob `json:"jobs"`
+	}
+	if err := as.getJSON(ctx, apiURL, &response); err != nil {
+		return jobs, err
+	}
+
+	for _, job := range response.Jobs {
+		if !job.IsListed {
+			continue
+		}
+
+		locations := []string{job.Location}
+		for _, secondary := range job.SecondaryLocations {
+			locations = append(locations, secondary.Location)
+		}
+		location := joinNonEmpty("; ", locations...)
+
+		workplaceType := normalizeWorkplaceType(job.WorkplaceType)
+		if job.IsRemote {
+			workplaceType = "remote"
+		}
+
+		description := strings.TrimSpace(job.DescriptionPlain)
+
+		if isRemoteJob(job.Title, company, location, workplaceType+" "+description) && isRelevantRole(job.Title, description) {
+			department := job.Department
+			if department == "" {
+				department = job.Team
+			}
+
+			jobURL := job.JobURL
+			if jobURL == "" {
+				jobURL = job.ApplyURL
+			}
+
+			jobListing := models.JobListing{
+				ID:             fmt.Sprintf("ashby-%s-%s", company, job.ID),
+				Title:          job.Title,
+				Company:        strings.Title(company), // Capitalize company name
+				Location:       location,
+				URL:            jobURL,
+				Description:    description,
+				Salary:         ashbyCompensation(job),
+				Source:         fmt.Sprintf("Ashby - %s", strings.Title(company)),
+				Department:     department,
+				EmploymentType: ashbyEmploymentType(job.EmploymentType),
+				WorkplaceType:  workplaceType,
+				ScrapedAt:      time.Now(),
+				PostedDate:     time.Now(),
+			}
+			if posted, err := time.Parse(time.RFC3339, job.PublishedAt); err == nil {
+				jobListing.PostedDate = posted
+			}
+			jobs = append(jobs, jobListing)
+		}
+	}
+
+	return jobs, nil
+}
+
+// ashbyCompensation summarises the compensation tiers of an Ashby job
+func ashbyCompensation(job ashbyJob) string {
+	if job.Compensation == nil {
+		return ""
+	}
+
+	// Multiple tiers (e.g. per location) are listed individually
+	if len(job.Compensation.CompensationTiers) > 1 {
+		var tiers []string
+		for _, tier := range job.Compensation.CompensationTiers {
+			if tier.Title != "" {
+				tiers = append(tiers, fmt.Sprintf("%s: %s", tier.Title, tier.TierSummary))
+			} else {
+				tiers = append(tiers, tier.TierSummary)
+			}
+		}
+		return strings.Join(tiers, "; ")
+	}
+
+	if job.Compensation.CompensationTierSummary != "" {
+		return job.Compensation.CompensationTierSummary
+	}
+	return job.Compensation.ScrapeableCompensationSalarySummary
+}
+
+// ashbyEmploymentType turns Ashby's enum values into readable labels
+func ashbyEmploymentType(employmentType string) string {
+	switch employmentType {
+	case "FullTime":
+		return "Full-time"
+	case "PartTime":
+		return "Part-time"
+	default:
+		return employmentType
+	}
+}
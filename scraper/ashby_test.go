@@ -0,0 +1,44 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScrapeSingleAshbyBoard(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/job-board/acme?includeCompensation=true": "ashby/acme.json",
+	})
+	as := NewAPIScraper(testConfig())
+
+	jobs, err := as.scrapeSingleAshbyBoard(context.Background(), server.URL+"/job-board", "acme")
+	if err != nil {
+		t.Fatalf("scrapeSingleAshbyBoard: %v", err)
+	}
+
+	// The unlisted job and the office manager are skipped
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2: %+v", len(jobs), jobs)
+	}
+
+	sre := jobs[0]
+	if sre.ID != "ashby-acme-7f3c" || sre.Location != "Remote; Toronto" || sre.WorkplaceType != "remote" {
+		t.Errorf("first job = %+v", sre)
+	}
+	if sre.Salary != "$140K - $170K" || sre.EmploymentType != "Full-time" || sre.Department != "Engineering" {
+		t.Errorf("first job salary = %q, employment type = %q, department = %q", sre.Salary, sre.EmploymentType, sre.Department)
+	}
+	if want := time.Date(2026, 9, 20, 9, 30, 0, 0, time.UTC); !sre.PostedDate.Equal(want) {
+		t.Errorf("posted date = %v, want %v", sre.PostedDate, want)
+	}
+
+	// Only the description says this one is remote and relevant
+	tooling := jobs[1]
+	if tooling.ID != "ashby-acme-8a1d" || tooling.Department != "Developer Experience" || tooling.Location != "Lisbon; Porto" {
+		t.Errorf("second job = %+v", tooling)
+	}
+	if tooling.URL != "https://jobs.ashbyhq.com/acme/8a1d/application" {
+		t.Errorf("second job should fall back to the apply URL, got %q", tooling.URL)
+	}
+}
@@ -0,0 +1,220 @@
+package scraper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"job-scraper/config"
+)
+
+// maxCachedBody caps the size of a response body stored in the cache
+const maxCachedBody = 20 << 20
+
+// cacheEntry is a stored response with the validators needed to revalidate it
+type cacheEntry struct {
+	URL          string      `json:"url"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	StoredAt     time.Time   `json:"stored_at"`
+}
+
+// cacheTransport keeps GET responses on disk. Entries younger than the TTL are served
+// without a request; older ones are revalidated with If-None-Match/If-Modified-Since
+// and a 304 replays the stored body, so the jobs parsed from it are reused.
+type cacheTransport struct {
+	base http.RoundTripper
+	dir  string
+	ttl  time.Duration
+}
+
+// newCacheTransport wraps base with the cache of cfg; without a directory it returns base unchanged
+func newCacheTransport(base http.RoundTripper, cfg config.CacheConfig) http.RoundTripper {
+	if cfg.Dir == "" {
+		return base
+	}
+	return &cacheTransport{base: base, dir: cfg.Dir, ttl: time.Duration(cfg.TTL)}
+}
+
+// RoundTrip serves req from the cache when possible and stores cacheable responses
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	path := t.path(req)
+	entry, err := loadCacheEntry(path)
+	if err != nil {
+		fmt.Printf("Error reading cache entry for %s: %v\n", redactedURL(req), err)
+	}
+
+	if entry != nil {
+		if t.ttl > 0 && time.Since(entry.StoredAt) < t.ttl {
+			return entry.response(req), nil
+		}
+
+		req = req.Clone(req.Context())
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		entry.StoredAt = time.Now()
+		t.store(path, entry)
+		return entry.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || !t.cacheable(resp) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(body) <= maxCachedBody {
+		t.store(path, &cacheEntry{
+			URL:          redactedURL(req), // Credentials in the query stay out of the cache directory
+			Header:       resp.Header.Clone(),
+			Body:         body,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			StoredAt:     time.Now(),
+		})
+	}
+	return resp, nil
+}
+
+// cacheable reports whether a response is worth storing: it must be revalidatable
+// or fresh for a while, and the server must not forbid storing it
+func (t *cacheTransport) cacheable(resp *http.Response) bool {
+	if strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return false
+	}
+	return t.ttl > 0 || resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+// cacheKeyIgnoredHeaders don't change the response, so they are left out of the cache key
+var cacheKeyIgnoredHeaders = map[string]bool{
+	"User-Agent":        true,
+	"Referer":           true,
+	"If-None-Match":     true,
+	"If-Modified-Since": true,
+}
+
+// path returns the cache file of a request. Every other request header is part of the key,
+// so responses fetched with different credentials (Authorization, an API key header or a
+// custom header) never mix.
+func (t *cacheTransport) path(req *http.Request) string {
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		if !cacheKeyIgnoredHeaders[http.CanonicalHeaderKey(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	io.WriteString(hash, req.URL.String())
+	for _, name := range names {
+		io.WriteString(hash, "\n"+http.CanonicalHeaderKey(name)+": "+strings.Join(req.Header.Values(name), ", "))
+	}
+	return filepath.Join(t.dir, hex.EncodeToString(hash.Sum(nil))+".json")
+}
+
+// store writes an entry, replacing the previous file atomically
+func (t *cacheTransport) store(path string, entry *cacheEntry) {
+	if err := saveCacheEntry(path, entry); err != nil {
+		fmt.Printf("Error writing cache entry for %s: %v\n", entry.URL, err)
+	}
+}
+
+// response builds the 200 response replayed from an entry
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(e.Body)))
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// loadCacheEntry reads a cache file; a missing file yields a nil entry
+func loadCacheEntry(path string) (*cacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// saveCacheEntry writes a cache file through a temporary file so readers never see a partial entry
+func saveCacheEntry(path string, entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,82 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"job-scraper/config"
+)
+
+func TestCachePathKeysOnHeaders(t *testing.T) {
+	cache := &cacheTransport{dir: t.TempDir()}
+
+	request := func(headers map[string]string) *http.Request {
+		req, err := http.NewRequest("GET", "https://data.usajobs.gov/api/search?Keyword=devops", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		return req
+	}
+
+	base := cache.path(request(map[string]string{"Authorization-Key": "first"}))
+
+	if cache.path(request(map[string]string{"Authorization-Key": "second"})) == base {
+		t.Error("requests with different API key headers share a cache entry")
+	}
+	if cache.path(request(map[string]string{"Authorization-Key": "first", "X-API-Key": "other"})) == base {
+		t.Error("a custom header should change the cache entry")
+	}
+	if cache.path(request(map[string]string{"Authorization-Key": "first", "User-Agent": "another agent"})) != base {
+		t.Error("the user agent should not change the cache entry")
+	}
+}
+
+func TestCacheRedactsQueryCredentials(t *testing.T) {
+	t.Setenv("TEST_JOBS_API_KEY", "s3cr3t-key")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("app_key") != "s3cr3t-key" {
+			http.Error(w, "missing key", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"jobs": []}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cfg := testConfig()
+	cfg.Cache = config.CacheConfig{Dir: dir, TTL: config.Duration(time.Hour)}
+	as := NewAPIScraper(cfg)
+
+	auth := config.AuthConfig{Type: "api_key", TokenEnv: "TEST_JOBS_API_KEY", In: "query", Name: "app_key"}
+	for i := 0; i < 2; i++ {
+		if _, _, err := as.sendRequest(context.Background(), apiRequest{url: server.URL + "/jobs?q=devops", auth: auth}); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("got %d cache entries, want 1", len(files))
+	}
+	entry, err := loadCacheEntry(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "s3cr3t-key") {
+		t.Errorf("cache entry holds the API key: %s", data)
+	}
+	if want := server.URL + "/jobs?app_key=REDACTED&q=devops"; entry.URL != want {
+		t.Errorf("entry URL = %q, want %q", entry.URL, want)
+	}
+}
@@ -0,0 +1,216 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// feedDateLayouts are the pubDate/updated formats seen in the wild
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 02 Jan 2006 15:04:05 Z",
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+var (
+	hiringTitleRegex = regexp.MustCompile(`(?i)^(.+?)\s+is\s+hiring(?:\s+an?)?\s*:?\s+(.+)$`)
+	atTitleRegex     = regexp.MustCompile(`(?i)^(.+?)\s+at\s+([^,()|]+?)(?:\s*[,(|].*)?$`)
+)
+
+// feedEntry is the format independent view of an RSS item or Atom entry
+type feedEntry struct {
+	ID          string
+	Title       string
+	Company     string
+	Location    string
+	URL         string
+	Description string
+	Published   string
+}
+
+func init() {
+	registerSource(sourceAdapter{
+		Type:     "feed",
+		Required: []string{"url"},
+		Fetch:    (*APIScraper).scrapeFeed,
+	})
+}
+
+// scrapeFeed scrapes an RSS 2.0 or Atom feed
+func (as *APIScraper) scrapeFeed(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
+	var jobs []models.JobListing
+
+	// Feeds are crawled pages like HTML sites, so robots.txt applies when configured
+	body, _, err := as.sendRequest(withRobots(ctx), apiRequest{
+		url:     apiSite.URL,
+		accept:  "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8",
+		headers: apiSite.Headers,
+		auth:    apiSite.Auth,
+	})
+	if errors.Is(err, errRobotsDisallowed) {
+		return jobs, nil
+	}
+	if err != nil {
+		return jobs, err
+	}
+
+	entries, err := parseFeed(body)
+	if err != nil {
+		return jobs, err
+	}
+
+	for i, entry := range entries {
+		title, company := splitFeedTitle(entry.Title)
+		if entry.Company != "" {
+			company = entry.Company
+			title = strings.TrimSpace(entry.Title)
+		}
+
+		if title != "" && isRemoteJob(title, company, entry.Location, entry.Description) && isRelevantRole(title, entry.Description) {
+			job := models.JobListing{
+				ID:          fmt.Sprintf("%s-%d", apiSite.Name, i+1),
+				Title:       title,
+				Company:     company,
+				Location:    entry.Location,
+				URL:         entry.URL,
+				Description: entry.Description,
+				Source:      apiSite.Name,
+				ScrapedAt:   time.Now(),
+				PostedDate:  time.Now(),
+			}
+			if entry.ID != "" {
+				job.ID = fmt.Sprintf("%s-%s", apiSite.Name, entry.ID)
+			}
+			if posted, ok := parseFeedDate(entry.Published); ok {
+				job.PostedDate = posted
+			}
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs, nil
+}
+
+// parseFeed extracts the entries of an RSS 2.0 or Atom document
+func parseFeed(body []byte) ([]feedEntry, error) {
+	doc, err := xmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feed XML: %v", err)
+	}
+
+	var entries []feedEntry
+	for _, node := range xmlquery.Find(doc, "//*[local-name()='item' or local-name()='entry']") {
+		entry := feedEntry{
+			ID:        childText(node, "guid", "id"),
+			Title:     childText(node, "title"),
+			Company:   childText(node, "company", "companyName", "job_listing:company", "hiringOrganization"),
+			Location:  childText(node, "location", "region", "job_listing:location"),
+			URL:       feedLink(node),
+			Published: childText(node, "pubDate", "published", "dc:date", "updated"),
+		}
+
+		// Prefer the full content over the summary
+		description := childText(node, "content:encoded", "content", "description", "summary")
+		entry.Description = htmlToText(description)
+
+		if entry.URL == "" && strings.HasPrefix(entry.ID, "http") {
+			entry.URL = entry.ID
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// childText returns the text of the first direct child matching one of names.
+// Names may carry a namespace prefix ("content:encoded"); unprefixed names match any prefix-free element.
+func childText(node *xmlquery.Node, names ...string) string {
+	for _, name := range names {
+		prefix, local, found := strings.Cut(name, ":")
+		if !found {
+			prefix, local = "", name
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != xmlquery.ElementNode || !strings.EqualFold(child.Data, local) || child.Prefix != prefix {
+				continue
+			}
+			if text := strings.TrimSpace(child.InnerText()); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// feedLink returns the entry URL: the text of an RSS <link> or the href of an Atom alternate link
+func feedLink(node *xmlquery.Node) string {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != xmlquery.ElementNode || child.Data != "link" || child.Prefix != "" {
+			continue
+		}
+		if href := child.SelectAttr("href"); href != "" {
+			if rel := child.SelectAttr("rel"); rel == "" || rel == "alternate" {
+				return href
+			}
+			continue
+		}
+		if text := strings.TrimSpace(child.InnerText()); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// splitFeedTitle separates the job title and company of a feed entry title.
+// Recognised forms: "Company is hiring a Title", "Company: Title", "Title at Company",
+// "Company | Title | Location" (HN "Who is hiring") and "Title - Company".
+func splitFeedTitle(raw string) (title, company string) {
+	raw = strings.TrimSpace(raw)
+
+	if match := hiringTitleRegex.FindStringSubmatch(raw); match != nil {
+		return strings.TrimSpace(match[2]), strings.TrimSpace(match[1])
+	}
+	if company, title, found := strings.Cut(raw, ": "); found {
+		return strings.TrimSpace(title), strings.TrimSpace(company)
+	}
+	if match := atTitleRegex.FindStringSubmatch(raw); match != nil {
+		return strings.TrimSpace(match[1]), strings.TrimSpace(match[2])
+	}
+	if parts := strings.Split(raw, " | "); len(parts) >= 2 {
+		return strings.TrimSpace(parts[1]), strings.TrimSpace(parts[0])
+	}
+	if i := strings.LastIndex(raw, " - "); i > 0 {
+		return strings.TrimSpace(raw[:i]), strings.TrimSpace(raw[i+3:])
+	}
+	return raw, ""
+}
+
+// parseFeedDate parses RSS pubDate and Atom published/updated values
+func parseFeedDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range feedDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
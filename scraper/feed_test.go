@@ -0,0 +1,142 @@
+package scraper
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"job-scraper/config"
+)
+
+func TestSplitFeedTitle(t *testing.T) {
+	tests := []struct {
+		raw, title, company string
+	}{
+		{"Acme is hiring a Senior DevOps Engineer", "Senior DevOps Engineer", "Acme"},
+		{"Acme is hiring: Platform Engineer", "Platform Engineer", "Acme"},
+		{"Acme: Platform Engineer", "Platform Engineer", "Acme"},
+		{"Site Reliability Engineer at Hooli (Remote)", "Site Reliability Engineer", "Hooli"},
+		{"Backend Engineer at Globex, Berlin", "Backend Engineer", "Globex"},
+		{"Initech | Backend Engineer | Remote (US)", "Backend Engineer", "Initech"},
+		{"Initech | Backend Engineer", "Backend Engineer", "Initech"},
+		{"Cloud Engineer - Full-Time - Umbrella", "Cloud Engineer - Full-Time", "Umbrella"},
+		{"  DevOps Engineer  ", "DevOps Engineer", ""},
+	}
+	for _, tt := range tests {
+		title, company := splitFeedTitle(tt.raw)
+		if title != tt.title || company != tt.company {
+			t.Errorf("splitFeedTitle(%q) = %q, %q; want %q, %q", tt.raw, title, company, tt.title, tt.company)
+		}
+	}
+}
+
+func TestParseFeedDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"Tue, 15 Sep 2026 10:00:00 +0000", time.Date(2026, 9, 15, 10, 0, 0, 0, time.UTC)},
+		{"Wed, 16 Sep 2026 08:30:00 GMT", time.Date(2026, 9, 16, 8, 30, 0, 0, time.UTC)},
+		{"2026-09-17T12:00:00Z", time.Date(2026, 9, 17, 12, 0, 0, 0, time.UTC)},
+		{"2026-09-18", time.Date(2026, 9, 18, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, ok := parseFeedDate(tt.value)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("parseFeedDate(%q) = %v, %v; want %v", tt.value, got, ok, tt.want)
+		}
+	}
+	if _, ok := parseFeedDate("last Tuesday"); ok {
+		t.Error("parseFeedDate accepted an unknown format")
+	}
+}
+
+func TestParseFeed(t *testing.T) {
+	t.Run("rss", func(t *testing.T) {
+		entries := parseFeedFile(t, "testdata/feed/rss.xml")
+		if len(entries) != 3 {
+			t.Fatalf("got %d entries, want 3", len(entries))
+		}
+
+		first := entries[0]
+		if first.ID != "rss-1" || first.Title != "Acme: Senior DevOps Engineer" || first.Location != "Anywhere in the World" {
+			t.Errorf("first entry = %+v", first)
+		}
+		if first.URL != "https://jobs.example.com/acme-senior-devops-engineer" || first.Description != "Run our Kubernetes clusters." {
+			t.Errorf("first entry URL = %q, description = %q; want the link and the full content", first.URL, first.Description)
+		}
+
+		// Namespaced job_listing elements carry the company and location
+		if second := entries[1]; second.Company != "Globex" || second.Location != "Remote (EU)" {
+			t.Errorf("second entry = %+v", second)
+		}
+	})
+
+	t.Run("atom", func(t *testing.T) {
+		entries := parseFeedFile(t, "testdata/feed/atom.xml")
+		if len(entries) != 2 {
+			t.Fatalf("got %d entries, want 2", len(entries))
+		}
+
+		// The alternate link wins over the enclosure, and content over summary
+		first := entries[0]
+		if first.URL != "https://jobs.example.com/hooli-sre" || first.Published != "2026-09-17T12:00:00Z" {
+			t.Errorf("first entry = %+v", first)
+		}
+		if first.Description != "Keep production healthy with Prometheus and Grafana." {
+			t.Errorf("first entry description = %q", first.Description)
+		}
+
+		// Without a link, an id that is a URL is used
+		if second := entries[1]; second.URL != "https://jobs.example.com/initech-backend" || second.Published != "2026-09-18T09:00:00Z" {
+			t.Errorf("second entry = %+v", second)
+		}
+	})
+
+	if _, err := parseFeed([]byte("<rss><channel><item>")); err == nil {
+		t.Error("parseFeed accepted broken XML")
+	}
+}
+
+// parseFeedFile parses a feed under testdata
+func parseFeedFile(t *testing.T, path string) []feedEntry {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := parseFeed(data)
+	if err != nil {
+		t.Fatalf("parseFeed: %v", err)
+	}
+	return entries
+}
+
+func TestScrapeFeed(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/jobs.rss": "feed/rss.xml",
+	})
+	as := NewAPIScraper(testConfig())
+
+	jobs, err := as.scrapeFeed(context.Background(), config.APISite{Name: "Feed", Type: "feed", URL: server.URL + "/jobs.rss"})
+	if err != nil {
+		t.Fatalf("scrapeFeed: %v", err)
+	}
+
+	// The account executive isn't relevant
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2: %+v", len(jobs), jobs)
+	}
+	if first := jobs[0]; first.ID != "Feed-rss-1" || first.Title != "Senior DevOps Engineer" || first.Company != "Acme" {
+		t.Errorf("first job = %+v", first)
+	}
+	if want := time.Date(2026, 9, 15, 10, 0, 0, 0, time.UTC); !jobs[0].PostedDate.Equal(want) {
+		t.Errorf("posted date = %v, want %v", jobs[0].PostedDate, want)
+	}
+
+	// An explicit company keeps the whole title
+	if second := jobs[1]; second.Title != "Platform Engineer" || second.Company != "Globex" || second.Location != "Remote (EU)" {
+		t.Errorf("second job = %+v", second)
+	}
+}
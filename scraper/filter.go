@@ -0,0 +1,193 @@
+package scraper
+
+import (
+	"strings"
+)
+
+// isRemoteJob checks if a job is remote based on various indicators
+func isRemoteJob(title, company, location, fullText string) bool {
+	// Convert to lowercase for case-insensitive matching
+	titleLower := strings.ToLower(title)
+	locationLower := strings.ToLower(location)
+	fullTextLower := strings.ToLower(fullText)
+
+	// Remote job indicators
+	remoteKeywords := []string{
+		"remote",
+		"work from home",
+		"wfh",
+		"virtual",
+		"distributed",
+		"telecommute",
+		"flexible location",
+		"anywhere",
+		"global",
+		"worldwide",
+		"us-remote",
+		"remote in",
+		"remote us",
+		"remote canada",
+	}
+
+	// Check if any remote keywords appear in title, location, or full text
+	for _, keyword := range remoteKeywords {
+		if strings.Contains(titleLower, keyword) ||
+			strings.Contains(locationLower, keyword) ||
+			strings.Contains(fullTextLower, keyword) {
+			return true
+		}
+	}
+
+	// Check for common non-remote indicators (if found, likely not remote)
+	nonRemoteKeywords := []string{
+		"on-site",
+		"onsite",
+		"in-person",
+		"office",
+		"headquarters",
+		"relocation required",
+		"must be local",
+	}
+
+	for _, keyword := range nonRemoteKeywords {
+		if strings.Contains(titleLower, keyword) ||
+			strings.Contains(locationLower, keyword) ||
+			strings.Contains(fullTextLower, keyword) {
+			return false
+		}
+	}
+
+	// If location is empty, might be remote
+	if location == "" {
+		return true
+	}
+
+	return false
+}
+
+// isRelevantRole checks if a job title/description matches relevant engineering roles
+func isRelevantRole(title, fullText string) bool {
+	// Convert to lowercase for case-insensitive matching
+	titleLower := strings.ToLower(title)
+	fullTextLower := strings.ToLower(fullText)
+
+	// Relevant role keywords
+	relevantKeywords := []string{
+		"system engineer",
+		"systems engineer",
+		"devops",
+		"dev ops",
+		"cloud engineer",
+		"sre",
+		"site reliability engineer",
+		"platform engineer",
+		"infrastructure engineer",
+		"reliability engineer",
+		"automation engineer",
+		"build engineer",
+		"release engineer",
+		"deployment engineer",
+		"kubernetes engineer",
+		"container engineer",
+		"aws engineer",
+		"azure engineer",
+		"gcp engineer",
+		"google cloud engineer",
+		"terraform engineer",
+		"ansible engineer",
+		"jenkins engineer",
+		"ci/cd engineer",
+		"monitoring engineer",
+		"observability engineer",
+		"security engineer",
+		"compliance engineer",
+		"network engineer",
+		"linux engineer",
+		"unix engineer",
+		"operations engineer",
+		"ops engineer",
+		"production engineer",
+		"backend engineer",
+		"api engineer",
+		"microservices engineer",
+		"distributed systems engineer",
+		"scalability engineer",
+		"performance engineer",
+		"data engineer",
+		"ml engineer",
+		"machine learning engineer",
+		"ai engineer",
+		"artificial intelligence engineer",
+	}
+
+	// Check if any relevant keywords appear in title or full text
+	for _, keyword := range relevantKeywords {
+		if strings.Contains(titleLower, keyword) ||
+			strings.Contains(fullTextLower, keyword) {
+			return true
+		}
+	}
+
+	// Check for specific technology keywords that indicate relevant roles
+	techKeywords := []string{
+		"kubernetes",
+		"docker",
+		"terraform",
+		"ansible",
+		"puppet",
+		"chef",
+		"jenkins",
+		"gitlab ci",
+		"github actions",
+		"aws",
+		"azure",
+		"gcp",
+		"google cloud",
+		"amazon web services",
+		"microservices",
+		"containerization",
+		"orchestration",
+		"monitoring",
+		"observability",
+		"prometheus",
+		"grafana",
+		"elk stack",
+		"elasticsearch",
+		"splunk",
+		"datadog",
+		"new relic",
+		"pagerduty",
+		"incident response",
+		"disaster recovery",
+		"high availability",
+		"load balancing",
+		"auto scaling",
+		"infrastructure as code",
+		"configuration management",
+		"linux",
+		"unix",
+		"bash",
+		"shell scripting",
+		"python",
+		"golang",
+		"go",
+		"ruby",
+		"powershell",
+		"networking",
+		"security",
+		"compliance",
+		"automation",
+	}
+
+	// Count how many tech keywords appear
+	techCount := 0
+	for _, keyword := range techKeywords {
+		if strings.Contains(titleLower, keyword) ||
+			strings.Contains(fullTextLower, keyword) {
+			techCount++
+		}
+	}
+
+	// If we find 2 or more tech keywords, it's likely a relevant role
+	return techCount >= 2
+}
@@ -0,0 +1,34 @@
+package scraper
+
+import "testing"
+
+// HTML and API sources share isRemoteJob. A country alone no longer makes a posting remote,
+// as it did for HTML sites before the sources were merged.
+func TestIsRemoteJob(t *testing.T) {
+	tests := []struct {
+		name            string
+		title, location string
+		fullText        string
+		want            bool
+	}{
+		{"remote location", "DevOps Engineer", "Remote - US", "", true},
+		{"remote in the title", "Remote SRE", "Denver, CO", "", true},
+		{"remote in the description", "Platform Engineer", "Berlin", "This role is fully remote.", true},
+		{"anywhere", "Platform Engineer", "Anywhere", "", true},
+		{"no location", "Platform Engineer", "", "", true},
+		{"country name", "DevOps Engineer", "New York, United States", "", false},
+		{"country code", "DevOps Engineer", "Austin, TX, USA", "", false},
+		{"short country code", "DevOps Engineer", "US", "", false},
+		{"city", "DevOps Engineer", "Berlin, Germany", "", false},
+		{"on-site without a location", "DevOps Engineer", "", "Work on-site with the team.", false},
+		{"office without a location", "DevOps Engineer", "", "Three days a week in our office.", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRemoteJob(tt.title, "Acme", tt.location, tt.fullText); got != tt.want {
+				t.Errorf("isRemoteJob(%q, %q, %q) = %v, want %v", tt.title, tt.location, tt.fullText, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package scraper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"job-scraper/config"
+)
+
+// errNoFixture is returned in replay mode for requests that were never recorded
+var errNoFixture = errors.New("no recorded fixture")
+
+// fixture is a recorded exchange. Text bodies are stored as is so fixtures can be read
+// and edited by hand; anything else (e.g. gzipped sitemaps) is stored base64 encoded.
+type fixture struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"request_body,omitempty"`
+	Status      int         `json:"status"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        string      `json:"body,omitempty"`
+	BodyBase64  string      `json:"body_base64,omitempty"`
+}
+
+// fixtureTransport records every response into a directory, or replays recorded responses
+// without touching the network. It sits directly above the network transport, so the
+// cache, retries and robots.txt checks behave on replay as they did while recording.
+type fixtureTransport struct {
+	base   http.RoundTripper
+	dir    string
+	replay bool
+}
+
+// newFixtureTransport wraps base with the fixtures of cfg; without a directory it returns base unchanged
+func newFixtureTransport(base http.RoundTripper, cfg config.FixturesConfig) http.RoundTripper {
+	if cfg.Dir == "" {
+		return base
+	}
+	return &fixtureTransport{base: base, dir: cfg.Dir, replay: cfg.Mode == "replay"}
+}
+
+// RoundTrip replays the fixture of req, or sends req and records the response
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	// Credentials are left out of the key, so fixtures replay with any key and never hold one
+	recordedURL := redactedURL(req)
+	recordedBody := redactedBody(req.Context(), reqBody)
+	path := fixturePath(t.dir, req.Method, recordedURL, recordedBody)
+
+	if t.replay {
+		recorded, err := loadFixture(path)
+		if err != nil {
+			return nil, err
+		}
+		if recorded == nil {
+			return nil, fmt.Errorf("%w for %s %s", errNoFixture, req.Method, recordedURL)
+		}
+		return recorded.response(req)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := &fixture{
+		Method:      req.Method,
+		URL:         recordedURL,
+		RequestBody: string(recordedBody),
+		Status:      resp.StatusCode,
+		Header:      resp.Header.Clone(),
+	}
+	if utf8.Valid(body) {
+		recorded.Body = string(body)
+	} else {
+		recorded.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+	// The body is stored decoded, so these no longer describe it
+	recorded.Header.Del("Content-Encoding")
+	recorded.Header.Del("Content-Length")
+
+	if err := saveFixture(path, recorded); err != nil {
+		fmt.Printf("Error recording fixture for %s: %v\n", recordedURL, err)
+	}
+	return resp, nil
+}
+
+// response builds the response replayed from a fixture
+func (f *fixture) response(req *http.Request) (*http.Response, error) {
+	body := []byte(f.Body)
+	if f.BodyBase64 != "" {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(f.BodyBase64); err != nil {
+			return nil, fmt.Errorf("invalid fixture body for %s: %v", f.URL, err)
+		}
+	}
+
+	status := f.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	header := f.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// fixturePath returns the file of a request: the host keeps the directory browsable and a
+// hash of the method, URL and body tells requests to the same host apart.
+// Headers are left out so credentials never influence, or end up in, a fixture name.
+func fixturePath(dir, method, rawURL string, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, method+" "+rawURL+"\n")
+	hash.Write(body)
+
+	host := "unknown"
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+		host = strings.ReplaceAll(parsed.Host, ":", "_")
+	}
+	return filepath.Join(dir, host+"-"+hex.EncodeToString(hash.Sum(nil))[:16]+".json")
+}
+
+// loadFixture reads a fixture file; a missing file yields a nil fixture
+func loadFixture(path string) (*fixture, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded fixture
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %v", path, err)
+	}
+	return &recorded, nil
+}
+
+// saveFixture writes a fixture file indented and without HTML escaping, so bodies stay
+// readable and diffs of re-recorded fixtures stay small
+func saveFixture(path string, recorded *fixture) error {
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(recorded); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data.Bytes(), 0644)
+}
@@ -0,0 +1,164 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"job-scraper/config"
+)
+
+func TestFixtureRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"echo":"`+string(body)+`"}`)
+	}))
+
+	recorder := newFixtureTransport(http.DefaultTransport, config.FixturesConfig{Dir: dir, Mode: "record"})
+	recorded := roundTrip(t, recorder, server.URL+"/jobs?page=1", "hello")
+	server.Close()
+
+	// Replaying never touches the network, which is gone by now
+	replayer := newFixtureTransport(http.DefaultTransport, config.FixturesConfig{Dir: dir, Mode: "replay"})
+	replayed := roundTrip(t, replayer, server.URL+"/jobs?page=1", "hello")
+	if replayed != recorded || replayed != `201 {"echo":"hello"}` {
+		t.Errorf("replayed %q, recorded %q", replayed, recorded)
+	}
+
+	// A different body is a different request
+	req, _ := http.NewRequest("POST", server.URL+"/jobs?page=1", strings.NewReader("other"))
+	if _, err := replayer.RoundTrip(req); !errors.Is(err, errNoFixture) {
+		t.Errorf("unrecorded request: err = %v, want errNoFixture", err)
+	}
+}
+
+func TestFixtureBinaryBody(t *testing.T) {
+	dir := t.TempDir()
+	gzipped := "\x1f\x8b\x08\x00\xff"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, gzipped)
+	}))
+
+	roundTrip(t, newFixtureTransport(http.DefaultTransport, config.FixturesConfig{Dir: dir, Mode: "record"}), server.URL+"/sitemap.xml.gz", "")
+	server.Close()
+
+	replayed := roundTrip(t, newFixtureTransport(http.DefaultTransport, config.FixturesConfig{Dir: dir, Mode: "replay"}), server.URL+"/sitemap.xml.gz", "")
+	if replayed != "200 "+gzipped {
+		t.Errorf("replayed %q", replayed)
+	}
+}
+
+// roundTrip sends a request through transport and returns "<status> <body>"
+func roundTrip(t *testing.T, transport http.RoundTripper, url, body string) string {
+	t.Helper()
+
+	method := "GET"
+	var reqBody io.Reader
+	if body != "" {
+		method = "POST"
+		reqBody = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Status[:3] + " " + string(data)
+}
+
+func TestScrapeSiteMissingFixture(t *testing.T) {
+	cfg := testConfig()
+	cfg.Fixtures = config.FixturesConfig{Dir: t.TempDir(), Mode: "replay"}
+	js := NewJobScraper(cfg)
+
+	result, err := js.ScrapeSite(context.Background(), config.Site{
+		Name:     "Unrecorded",
+		URL:      "https://example.com/never-recorded",
+		Selector: "li.job",
+	})
+	if !errors.Is(err, errNoFixture) {
+		t.Fatalf("err = %v, want errNoFixture", err)
+	}
+	if len(result.Jobs) != 0 {
+		t.Errorf("got %d jobs, want none", len(result.Jobs))
+	}
+}
+
+func TestMissingFixtureNotRetried(t *testing.T) {
+	cfg := config.ScraperConfig{
+		Retry:    config.RetryConfig{MaxAttempts: 3},
+		Fixtures: config.FixturesConfig{Dir: t.TempDir(), Mode: "replay"},
+	}
+	as := NewAPIScraper(cfg)
+
+	ctx, stats := withRequestStats(context.Background())
+	var v interface{}
+	if err := as.getJSON(ctx, "https://example.com/jobs.json", &v); !errors.Is(err, errNoFixture) {
+		t.Fatalf("err = %v, want errNoFixture", err)
+	}
+	if stats.attempts != 1 || stats.retries != 0 {
+		t.Errorf("attempts = %d, retries = %d; want 1 and 0", stats.attempts, stats.retries)
+	}
+}
+
+func TestFixtureRedactsCredentials(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"jobs": []}`)
+	}))
+
+	auth := config.AuthConfig{Type: "api_key", TokenEnv: "TEST_JOBS_API_KEY", In: "query", Name: "app_key"}
+	request := apiRequest{
+		method: "POST",
+		url:    server.URL + "/search?q=devops",
+		body:   strings.NewReader(`{"key": "s3cr3t-key"}`),
+		auth:   auth,
+	}
+
+	t.Setenv("TEST_JOBS_API_KEY", "s3cr3t-key")
+	recorder := NewAPIScraper(config.ScraperConfig{Fixtures: config.FixturesConfig{Dir: dir, Mode: "record"}})
+	if _, _, err := recorder.sendRequest(context.Background(), request); err != nil {
+		t.Fatalf("recording: %v", err)
+	}
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("got %d fixtures, want 1", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "s3cr3t-key") {
+		t.Errorf("fixture holds the API key: %s", data)
+	}
+	if !strings.Contains(string(data), "app_key=REDACTED") {
+		t.Errorf("fixture URL has no redacted key: %s", data)
+	}
+
+	// The key is not part of the fixture's name, so another key replays it
+	t.Setenv("TEST_JOBS_API_KEY", "another-key")
+	request.body = strings.NewReader(`{"key": "another-key"}`)
+	replayer := NewAPIScraper(config.ScraperConfig{Fixtures: config.FixturesConfig{Dir: dir, Mode: "replay"}})
+	if body, _, err := replayer.sendRequest(context.Background(), request); err != nil || string(body) != `{"jobs": []}` {
+		t.Errorf("replaying with another key: %q, %v", body, err)
+	}
+}
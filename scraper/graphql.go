@@ -0,0 +1,144 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// graphQLError is a single entry of the "errors" array of a GraphQL response
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+func init() {
+	registerSource(sourceAdapter{
+		Type:     "graphql",
+		Required: []string{"url"},
+		Validate: func(apiSite config.APISite) error {
+			if apiSite.GraphQL.Query == "" && apiSite.GraphQL.QueryFile == "" {
+				return fmt.Errorf("graphql requires a query or query_file")
+			}
+			return validateExtraction(apiSite)
+		},
+		Fetch: (*APIScraper).scrapeGraphQLAPI,
+	})
+}
+
+// scrapeGraphQLAPI queries a GraphQL job source, following Relay-style pageInfo cursors
+func (as *APIScraper) scrapeGraphQLAPI(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
+	var jobs []models.JobListing
+
+	query, err := loadGraphQLQuery(apiSite.GraphQL)
+	if err != nil {
+		return jobs, err
+	}
+
+	variables := make(map[string]interface{}, len(apiSite.GraphQL.Variables))
+	for name, value := range apiSite.GraphQL.Variables {
+		variables[name] = renderTemplateValue(value, nil)
+	}
+
+	cursorVariable := apiSite.GraphQL.CursorVariable
+	if cursorVariable == "" {
+		cursorVariable = "after"
+	}
+
+	maxPages := apiSite.Pagination.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+
+	itemCount := 0
+	for page := 0; page < maxPages; page++ {
+		payload, err := json.Marshal(map[string]interface{}{
+			"query":     query,
+			"variables": variables,
+		})
+		if err != nil {
+			return jobs, fmt.Errorf("failed to encode GraphQL request: %v", err)
+		}
+
+		body, _, err := as.sendRequest(ctx, apiRequest{
+			method:      "POST",
+			url:         apiSite.URL,
+			body:        bytes.NewReader(payload),
+			contentType: "application/json",
+			headers:     apiSite.Headers,
+			auth:        apiSite.Auth,
+		})
+		if err != nil {
+			return jobs, err
+		}
+
+		var response interface{}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return jobs, fmt.Errorf("failed to parse GraphQL JSON: %v", err)
+		}
+		if err := graphQLErrors(body); err != nil {
+			return jobs, err
+		}
+
+		items, err := genericAPIItems(response, apiSite.RootPath)
+		if err != nil {
+			return jobs, err
+		}
+		jobs = append(jobs, as.extractJobsFromGenericAPI(items, apiSite, itemCount)...)
+		itemCount += len(items)
+
+		if apiSite.GraphQL.PageInfoPath == "" || len(items) == 0 {
+			break
+		}
+
+		// Read with evalPath: lookupValue skips objects without a text-like key, which pageInfo is
+		var pageInfo map[string]interface{}
+		if values, err := evalPath(response, apiSite.GraphQL.PageInfoPath); err == nil && len(values) == 1 {
+			pageInfo, _ = values[0].(map[string]interface{})
+		}
+		hasNextPage, _ := pageInfo["hasNextPage"].(bool)
+		endCursor, _ := pageInfo["endCursor"].(string)
+		if !hasNextPage || endCursor == "" || endCursor == variables[cursorVariable] {
+			break
+		}
+		variables[cursorVariable] = endCursor
+	}
+
+	return jobs, nil
+}
+
+// loadGraphQLQuery returns the inline query or reads it from QueryFile
+func loadGraphQLQuery(cfg config.GraphQLConfig) (string, error) {
+	if cfg.QueryFile != "" {
+		data, err := os.ReadFile(cfg.QueryFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read GraphQL query file %s: %w", cfg.QueryFile, err)
+		}
+		return string(data), nil
+	}
+	if strings.TrimSpace(cfg.Query) == "" {
+		return "", fmt.Errorf("graphql source requires a query or query_file")
+	}
+	return cfg.Query, nil
+}
+
+// graphQLErrors turns the "errors" array of a GraphQL response into a Go error
+func graphQLErrors(body []byte) error {
+	var response struct {
+		Errors []graphQLError `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || len(response.Errors) == 0 {
+		return nil
+	}
+
+	var messages []string
+	for _, e := range response.Errors {
+		messages = append(messages, e.Message)
+	}
+	return fmt.Errorf("GraphQL errors: %s", strings.Join(messages, "; "))
+}
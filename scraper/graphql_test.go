@@ -0,0 +1,148 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"job-scraper/config"
+)
+
+// newGraphQLServer serves three pages of two jobs, chained by the cursors "c1" and "c2".
+// It records the "after" variable of every request.
+func newGraphQLServer(t *testing.T, query string, afters *[]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		if request.Query != query {
+			t.Errorf("query = %q, want the query file", request.Query)
+		}
+		if request.Variables["first"] != float64(2) {
+			t.Errorf("variables = %v, want first = 2", request.Variables)
+		}
+
+		after, _ := request.Variables["after"].(string)
+		*afters = append(*afters, after)
+
+		page := map[string]int{"": 0, "c1": 1, "c2": 2}[after]
+		var edges []interface{}
+		for i := page * 2; i < page*2+2; i++ {
+			edges = append(edges, map[string]interface{}{"node": map[string]interface{}{
+				"id":       i + 1,
+				"title":    "Platform Engineer",
+				"company":  "Acme",
+				"location": "Remote",
+				"url":      fmt.Sprintf("https://jobs.example.com/%d", i+1),
+			}})
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"jobs": map[string]interface{}{
+			"edges":    edges,
+			"pageInfo": map[string]interface{}{"hasNextPage": page < 2, "endCursor": fmt.Sprintf("c%d", page+1)},
+		}}})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// graphQLSite returns a site reading the query from testdata
+func graphQLSite(serverURL string) config.APISite {
+	return config.APISite{
+		Name:     "GraphQL",
+		Type:     "graphql",
+		URL:      serverURL,
+		RootPath: "data.jobs.edges[*].node",
+		Fields:   config.FieldMapping{ID: "id", Title: "title", Company: "company", Location: "location", URL: "url"},
+		GraphQL: config.GraphQLConfig{
+			QueryFile:    "testdata/graphql/jobs.graphql",
+			Variables:    map[string]interface{}{"first": 2},
+			PageInfoPath: "data.jobs.pageInfo",
+		},
+	}
+}
+
+func TestScrapeGraphQLAPI(t *testing.T) {
+	query, err := os.ReadFile("testdata/graphql/jobs.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("follows cursors until hasNextPage is false", func(t *testing.T) {
+		var afters []string
+		server := newGraphQLServer(t, string(query), &afters)
+
+		jobs, err := NewAPIScraper(testConfig()).scrapeGraphQLAPI(context.Background(), graphQLSite(server.URL))
+		if err != nil {
+			t.Fatalf("scrapeGraphQLAPI: %v", err)
+		}
+		if strings.Join(afters, ",") != ",c1,c2" {
+			t.Errorf("after variables = %q, want [\"\" c1 c2]", afters)
+		}
+		if len(jobs) != 6 || jobs[0].ID != "GraphQL-1" || jobs[5].URL != "https://jobs.example.com/6" {
+			t.Errorf("got %d jobs: %+v", len(jobs), jobs)
+		}
+	})
+
+	t.Run("max_pages caps the requests", func(t *testing.T) {
+		var afters []string
+		server := newGraphQLServer(t, string(query), &afters)
+
+		site := graphQLSite(server.URL)
+		site.Pagination.MaxPages = 2
+		jobs, err := NewAPIScraper(testConfig()).scrapeGraphQLAPI(context.Background(), site)
+		if err != nil {
+			t.Fatalf("scrapeGraphQLAPI: %v", err)
+		}
+		if len(afters) != 2 || len(jobs) != 4 {
+			t.Errorf("made %d requests and got %d jobs, want 2 and 4", len(afters), len(jobs))
+		}
+	})
+
+	t.Run("without page_info_path only one page is read", func(t *testing.T) {
+		var afters []string
+		server := newGraphQLServer(t, string(query), &afters)
+
+		site := graphQLSite(server.URL)
+		site.GraphQL.PageInfoPath = ""
+		if _, err := NewAPIScraper(testConfig()).scrapeGraphQLAPI(context.Background(), site); err != nil {
+			t.Fatalf("scrapeGraphQLAPI: %v", err)
+		}
+		if len(afters) != 1 {
+			t.Errorf("made %d requests, want 1", len(afters))
+		}
+	})
+
+	t.Run("missing query file", func(t *testing.T) {
+		site := graphQLSite("http://127.0.0.1:0")
+		site.GraphQL.QueryFile = "testdata/graphql/missing.graphql"
+		_, err := NewAPIScraper(testConfig()).scrapeGraphQLAPI(context.Background(), site)
+		if err == nil || !strings.Contains(err.Error(), "failed to read GraphQL query file") {
+			t.Errorf("err = %v, want a query file error", err)
+		}
+	})
+}
+
+func TestScrapeGraphQLAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": null, "errors": [{"message": "Cannot query field \"jobz\""}, {"message": "rate limited"}]}`))
+	}))
+	defer server.Close()
+
+	site := graphQLSite(server.URL)
+	_, err := NewAPIScraper(testConfig()).scrapeGraphQLAPI(context.Background(), site)
+	if err == nil || err.Error() != `GraphQL errors: Cannot query field "jobz"; rate limited` {
+		t.Errorf("err = %v", err)
+	}
+}
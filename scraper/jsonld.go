@@ -0,0 +1,183 @@
+package scraper
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"job-scraper/models"
+)
+
+// jobPostingLDSelector matches the script blocks that may hold schema.org JobPosting data
+const jobPostingLDSelector = `script[type="application/ld+json"]`
+
+// parseJobPostingsLD returns every schema.org JobPosting found in a JSON-LD block.
+// Blocks may hold a single object, an array or an @graph of nodes.
+func parseJobPostingsLD(raw string) []map[string]interface{} {
+	var data interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &data); err != nil {
+		return nil
+	}
+
+	var postings []map[string]interface{}
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch v := node.(type) {
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		case map[string]interface{}:
+			if hasLDType(v, "JobPosting") {
+				postings = append(postings, v)
+				return
+			}
+			if graph, ok := v["@graph"]; ok {
+				walk(graph)
+			}
+		}
+	}
+	walk(data)
+
+	return postings
+}
+
+// hasLDType reports whether a JSON-LD node declares the given @type
+func hasLDType(node map[string]interface{}, want string) bool {
+	switch t := node["@type"].(type) {
+	case string:
+		return strings.EqualFold(strings.TrimPrefix(t, "schema:"), want)
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok && strings.EqualFold(strings.TrimPrefix(s, "schema:"), want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jobFromLD converts a JobPosting node into a JobListing.
+// pageURL is used when the posting doesn't carry its own url.
+func jobFromLD(posting map[string]interface{}, pageURL string) models.JobListing {
+	job := models.JobListing{
+		Title:          valueToString(posting["title"]),
+		Company:        valueToString(lookupValue(posting, "hiringOrganization.name|hiringOrganization")),
+		URL:            valueToString(posting["url"]),
+		Description:    escapedHTMLToText(valueToString(posting["description"])),
+		Salary:         ldSalary(posting["baseSalary"]),
+		EmploymentType: valueToString(posting["employmentType"]),
+		ScrapedAt:      time.Now(),
+		PostedDate:     time.Now(),
+	}
+	if job.URL == "" {
+		job.URL = pageURL
+	}
+	if id := valueToString(lookupValue(posting, "identifier.value|identifier")); id != "" {
+		job.ID = id
+	}
+	if posted, ok := parseDateValue(posting["datePosted"], ""); ok {
+		job.PostedDate = posted
+	}
+	if validThrough, ok := parseDateValue(posting["validThrough"], ""); ok {
+		job.ValidThrough = validThrough
+	}
+
+	var locations []string
+	for _, place := range ldList(posting["jobLocation"]) {
+		if location := ldPlace(place); location != "" {
+			locations = append(locations, location)
+		}
+	}
+	job.Location = strings.Join(locations, "; ")
+
+	// TELECOMMUTE marks remote roles; applicantLocationRequirements restricts where candidates may live
+	if strings.EqualFold(valueToString(posting["jobLocationType"]), "TELECOMMUTE") {
+		job.WorkplaceType = "remote"
+
+		var regions []string
+		for _, requirement := range ldList(posting["applicantLocationRequirements"]) {
+			if region := valueToString(requirement); region != "" {
+				regions = append(regions, region)
+			}
+		}
+
+		remote := "Remote"
+		if len(regions) > 0 {
+			remote += " (" + strings.Join(regions, ", ") + ")"
+		}
+		if job.Location == "" {
+			job.Location = remote
+		} else {
+			job.Location = remote + "; " + job.Location
+		}
+	}
+
+	return job
+}
+
+// ldList normalises a JSON-LD value that may be a single node or an array of nodes
+func ldList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	default:
+		return []interface{}{v}
+	}
+}
+
+// ldPlace renders a schema.org Place as "City, Region, Country"
+func ldPlace(place interface{}) string {
+	node, ok := place.(map[string]interface{})
+	if !ok {
+		return valueToString(place)
+	}
+
+	address, ok := node["address"].(map[string]interface{})
+	if !ok {
+		return joinNonEmpty(", ", valueToString(node["address"]), valueToString(node["name"]))
+	}
+
+	return joinNonEmpty(", ",
+		valueToString(address["addressLocality"]),
+		valueToString(address["addressRegion"]),
+		valueToString(address["addressCountry"]),
+	)
+}
+
+// ldSalary renders a schema.org MonetaryAmount, e.g. "USD 120000 - 150000 YEAR"
+func ldSalary(value interface{}) string {
+	salary, ok := value.(map[string]interface{})
+	if !ok {
+		return valueToString(value)
+	}
+
+	currency := valueToString(salary["currency"])
+	amount, ok := salary["value"].(map[string]interface{})
+	if !ok {
+		return joinNonEmpty(" ", currency, valueToString(salary["value"]))
+	}
+
+	min := ldNumber(amount["minValue"])
+	max := ldNumber(amount["maxValue"])
+	if min == 0 && max == 0 {
+		min = ldNumber(amount["value"])
+	}
+	return formatSalaryRange(min, max, currency, valueToString(amount["unitText"]))
+}
+
+// ldNumber reads a number that may be encoded as a JSON number or a string
+func ldNumber(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		if number, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", ""), 64); err == nil {
+			return number
+		}
+	}
+	return 0
+}
@@ -0,0 +1,158 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"job-scraper/config"
+)
+
+func TestParseJobPostingsLD(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		titles []string
+	}{
+		{"object", `{"@type": "JobPosting", "title": "SRE"}`, []string{"SRE"}},
+		{"array", `[{"@type": "JobPosting", "title": "SRE"}, {"@type": "Organization", "name": "Acme"}, {"@type": "JobPosting", "title": "DevOps"}]`, []string{"SRE", "DevOps"}},
+		{"graph", `{"@context": "https://schema.org", "@graph": [{"@type": "WebPage"}, {"@type": "JobPosting", "title": "SRE"}]}`, []string{"SRE"}},
+		{"prefixed type", `{"@type": "schema:JobPosting", "title": "SRE"}`, []string{"SRE"}},
+		{"type list", `{"@type": ["Thing", "JobPosting"], "title": "SRE"}`, []string{"SRE"}},
+		{"no postings", `{"@type": "Organization", "name": "Acme"}`, nil},
+		{"invalid JSON", `{"@type": "JobPosting",`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postings := parseJobPostingsLD(tt.raw)
+			if len(postings) != len(tt.titles) {
+				t.Fatalf("got %d postings, want %d", len(postings), len(tt.titles))
+			}
+			for i, title := range tt.titles {
+				if got := postings[i]["title"]; got != title {
+					t.Errorf("posting %d title = %v, want %q", i, got, title)
+				}
+			}
+		})
+	}
+}
+
+func TestJobFromLD(t *testing.T) {
+	postings := parseJobPostingsLD(`{
+		"@type": "JobPosting",
+		"title": "Site Reliability Engineer",
+		"identifier": {"@type": "PropertyValue", "value": "SRE-7"},
+		"hiringOrganization": {"@type": "Organization", "name": "Acme"},
+		"datePosted": "2026-09-01",
+		"validThrough": "2026-10-31",
+		"employmentType": "FULL_TIME",
+		"jobLocationType": "TELECOMMUTE",
+		"applicantLocationRequirements": [{"@type": "Country", "name": "Germany"}, {"@type": "Country", "name": "France"}],
+		"jobLocation": {"@type": "Place", "address": {"addressLocality": "Berlin", "addressCountry": "DE"}},
+		"baseSalary": {"@type": "MonetaryAmount", "currency": "EUR", "value": {"minValue": 70000, "maxValue": "90,000", "unitText": "YEAR"}},
+		"description": "&lt;p&gt;Keep &lt;b&gt;Kubernetes&lt;/b&gt; running.&lt;/p&gt;"
+	}`)
+	if len(postings) != 1 {
+		t.Fatalf("got %d postings, want 1", len(postings))
+	}
+
+	job := jobFromLD(postings[0], "https://jobs.example.com/sre")
+	if job.Title != "Site Reliability Engineer" || job.Company != "Acme" || job.ID != "SRE-7" {
+		t.Errorf("title, company, id = %q, %q, %q", job.Title, job.Company, job.ID)
+	}
+	if want := "Remote (Germany, France); Berlin, DE"; job.Location != want {
+		t.Errorf("location = %q, want %q", job.Location, want)
+	}
+	if job.WorkplaceType != "remote" {
+		t.Errorf("workplace type = %q, want remote", job.WorkplaceType)
+	}
+	if job.Description != "Keep Kubernetes running." {
+		t.Errorf("description = %q", job.Description)
+	}
+	if job.Salary == "" {
+		t.Error("salary is empty")
+	}
+	if job.EmploymentType != "FULL_TIME" {
+		t.Errorf("employment type = %q", job.EmploymentType)
+	}
+	if want := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC); !job.PostedDate.Equal(want) {
+		t.Errorf("posted = %v, want %v", job.PostedDate, want)
+	}
+	if want := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC); !job.ValidThrough.Equal(want) {
+		t.Errorf("valid through = %v, want %v", job.ValidThrough, want)
+	}
+	// Without its own url the posting points at the page it was found on
+	if job.URL != "https://jobs.example.com/sre" {
+		t.Errorf("url = %q", job.URL)
+	}
+}
+
+func TestScrapeSiteJSONLD(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/filtered":               "jsonld_board/filtered.html",
+		"/jobs":                   "jsonld_board/mixed1.html",
+		"/jobs?page=2":            "jsonld_board/mixed2.html",
+		"/jobs/platform-engineer": "jsonld_board/detail.html",
+	})
+
+	t.Run("filtered JSON-LD does not fall back to cards", func(t *testing.T) {
+		js := NewJobScraper(testConfig())
+		result, err := js.ScrapeSite(context.Background(), config.Site{
+			Name:     "Acme",
+			URL:      server.URL + "/filtered",
+			Selector: "li.job",
+		})
+		if err != nil {
+			t.Fatalf("ScrapeSite: %v", err)
+		}
+		if len(result.Jobs) != 0 {
+			t.Errorf("got %d jobs, want none: %+v", len(result.Jobs), result.Jobs)
+		}
+	})
+
+	// Page 1 is described by JSON-LD, page 2 only has cards
+	pages := []struct {
+		name   string
+		follow bool
+	}{
+		{"cards", false},
+		{"follow links", true},
+	}
+	for _, tt := range pages {
+		t.Run("choice per page with "+tt.name, func(t *testing.T) {
+			js := NewJobScraper(testConfig())
+			result, err := js.ScrapeSite(context.Background(), config.Site{
+				Name:        "Acme",
+				URL:         server.URL + "/jobs",
+				Selector:    "li.job",
+				FollowLinks: tt.follow,
+				Pagination:  config.HTMLPagination{NextSelector: "a.next", MaxPages: 3},
+			})
+			if err != nil {
+				t.Fatalf("ScrapeSite: %v", err)
+			}
+
+			want := []struct{ id, title, url string }{
+				{"Acme-JD-1", "Senior DevOps Engineer", server.URL + "/jobs/senior-devops-engineer"},
+				{"Acme-2", "Platform Engineer", server.URL + "/jobs/platform-engineer"},
+			}
+			if len(result.Jobs) != len(want) {
+				t.Fatalf("got %d jobs, want %d: %+v", len(result.Jobs), len(want), result.Jobs)
+			}
+			for i, w := range want {
+				job := result.Jobs[i]
+				if job.ID != w.id || job.Title != w.title || job.URL != w.url {
+					t.Errorf("job %d = {%q %q %q}, want %+v", i, job.ID, job.Title, job.URL, w)
+				}
+			}
+
+			// The card of page 2 only gets the detail page's description when it was followed
+			platform := result.Jobs[1]
+			hasDetail := platform.Description == "Build our internal platform with Terraform."
+			if hasDetail != tt.follow {
+				t.Errorf("description = %q with follow_links %v", platform.Description, tt.follow)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package scraper
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pathStep is a single segment of a JSON path: an object key, an array index or a wildcard
+type pathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parsePath splits a JSONPath-style expression such as "$.data.results[*].title" into steps.
+// Supported syntax: dotted keys, [n] indexes, [*] or .* wildcards and ["quoted key"] segments.
+func parsePath(path string) ([]pathStep, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, ".")
+
+	var steps []pathStep
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed bracket in path %q", path)
+			}
+			inner := strings.TrimSpace(path[i+1 : i+end])
+			i += end + 1
+
+			switch {
+			case inner == "*":
+				steps = append(steps, pathStep{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0]:
+				steps = append(steps, pathStep{key: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %q in path %q", inner, path)
+				}
+				steps = append(steps, pathStep{index: index, isIndex: true})
+			}
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			key := path[i : i+end]
+			i += end
+
+			if key == "*" {
+				steps = append(steps, pathStep{wildcard: true})
+			} else {
+				steps = append(steps, pathStep{key: key})
+			}
+		}
+	}
+
+	return steps, nil
+}
+
+// evalPath returns every value matched by path in v. An empty path matches v itself.
+func evalPath(v interface{}, path string) ([]interface{}, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := []interface{}{v}
+	for _, step := range steps {
+		var next []interface{}
+		for _, value := range current {
+			switch node := value.(type) {
+			case map[string]interface{}:
+				if step.wildcard {
+					// Visit keys in order so results are deterministic
+					keys := make([]string, 0, len(node))
+					for key := range node {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						next = append(next, node[key])
+					}
+				} else if child, ok := node[step.key]; ok && !step.isIndex {
+					next = append(next, child)
+				}
+			case []interface{}:
+				switch {
+				case step.wildcard:
+					next = append(next, node...)
+				case step.isIndex:
+					index := step.index
+					if index < 0 {
+						index += len(node)
+					}
+					if index >= 0 && index < len(node) {
+						next = append(next, node[index])
+					}
+				}
+			}
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// lookupValue evaluates a field expression against a job item.
+// Alternatives are separated with "|" and the first non-empty match wins;
+// an alternative wrapped in single quotes is returned as a literal.
+func lookupValue(item interface{}, expr string) interface{} {
+	for _, alternative := range strings.Split(expr, "|") {
+		alternative = strings.TrimSpace(alternative)
+		if alternative == "" {
+			continue
+		}
+
+		if len(alternative) >= 2 && alternative[0] == '\'' && alternative[len(alternative)-1] == '\'' {
+			return alternative[1 : len(alternative)-1]
+		}
+
+		values, err := evalPath(item, alternative)
+		if err != nil || len(values) == 0 {
+			continue
+		}
+
+		var value interface{} = values
+		if len(values) == 1 {
+			value = values[0]
+		}
+		if valueToString(value) != "" {
+			return value
+		}
+	}
+	return nil
+}
+
+// lookupString evaluates a field expression and renders the match as text
+func lookupString(item interface{}, expr string) string {
+	return valueToString(lookupValue(item, expr))
+}
+
+// valueToString renders a decoded JSON value as display text
+func valueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			if s := valueToString(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		// Objects are usually wrappers such as {"name": "Berlin"}
+		for _, key := range []string{"name", "label", "title", "value", "text"} {
+			if s := valueToString(v[key]); s != "" {
+				return s
+			}
+		}
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// commonDateLayouts are tried when a date field has no explicit format
+var commonDateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"Jan 2, 2006",
+	"January 2, 2006",
+}
+
+// parseDateValue converts a date field into a time.
+// format is a Go layout or one of "rfc3339", "unix" (seconds) and "unix_ms";
+// when empty, numbers are treated as epoch timestamps and strings are matched against common layouts.
+func parseDateValue(value interface{}, format string) (time.Time, bool) {
+	switch strings.ToLower(format) {
+	case "unix", "unix_ms":
+		timestamp, err := strconv.ParseFloat(valueToString(value), 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		if strings.EqualFold(format, "unix_ms") {
+			return time.UnixMilli(int64(timestamp)), true
+		}
+		return time.Unix(int64(timestamp), 0), true
+	case "rfc3339":
+		format = time.RFC3339
+	}
+
+	if number, ok := value.(float64); ok {
+		// Anything past the year 33658 in seconds must be milliseconds
+		if number > 1e12 {
+			return time.UnixMilli(int64(number)), true
+		}
+		return time.Unix(int64(number), 0), true
+	}
+
+	text := valueToString(value)
+	if text == "" {
+		return time.Time{}, false
+	}
+
+	layouts := commonDateLayouts
+	if format != "" {
+		layouts = []string{format}
+	}
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
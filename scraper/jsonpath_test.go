@@ -0,0 +1,122 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// decodeJSON decodes a JSON document the way API responses are decoded
+func decodeJSON(t *testing.T, data string) interface{} {
+	t.Helper()
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestEvalPath(t *testing.T) {
+	doc := decodeJSON(t, `{
+		"data": {"results": [
+			{"title": "DevOps Engineer", "tags": ["aws", "k8s"]},
+			{"title": "SRE", "tags": ["gcp"]}
+		]},
+		"meta": {"job-count": 2, "b": 2, "a": 1}
+	}`)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", fmt.Sprint([]interface{}{doc})},
+		{"$.data.results[*].title", "[DevOps Engineer SRE]"},
+		{"data.results[1].title", "[SRE]"},
+		{"data.results[-1].tags[0]", "[gcp]"},
+		{"data.results[*].tags[*]", "[aws k8s gcp]"},
+		{`meta["job-count"]`, "[2]"},
+		{"meta.*", "[1 2 2]"}, // Keys are visited in order
+		{"data.results[5].title", "[]"},
+		{"data.missing.title", "[]"},
+		{"data.results.title", "[]"},
+	}
+
+	for _, tt := range tests {
+		got, err := evalPath(doc, tt.path)
+		if err != nil {
+			t.Errorf("evalPath(%q): %v", tt.path, err)
+			continue
+		}
+		if fmt.Sprint(got) != tt.want {
+			t.Errorf("evalPath(%q) = %v, want %s", tt.path, got, tt.want)
+		}
+	}
+
+	for _, path := range []string{"data.results[*", "data.results[x]"} {
+		if _, err := evalPath(doc, path); err == nil {
+			t.Errorf("evalPath(%q): want an error", path)
+		}
+	}
+}
+
+func TestLookupString(t *testing.T) {
+	item := decodeJSON(t, `{
+		"title": "  Platform Engineer ",
+		"empty": "",
+		"remote": true,
+		"salary": 120000.5,
+		"office": {"name": "Berlin"},
+		"locations": [{"label": "Remote"}, {"label": ""}, {"label": "EU"}]
+	}`)
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"title", "Platform Engineer"},
+		{"empty | title", "Platform Engineer"},
+		{"missing | 'Worldwide'", "Worldwide"},
+		{"remote", "true"},
+		{"salary", "120000.5"},
+		{"office", "Berlin"},
+		{"locations[*]", "Remote, EU"},
+		{"missing | empty", ""},
+	}
+
+	for _, tt := range tests {
+		if got := lookupString(item, tt.expr); got != tt.want {
+			t.Errorf("lookupString(%q) = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseDateValue(t *testing.T) {
+	tests := []struct {
+		value  interface{}
+		format string
+		want   time.Time
+		ok     bool
+	}{
+		{"2026-09-01T10:00:00Z", "", time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC), true},
+		{"2026-09-01", "", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), true},
+		{"September 1, 2026", "", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), true},
+		{"01/09/2026", "02/01/2006", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), true},
+		{"2026-09-01T10:00:00Z", "rfc3339", time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC), true},
+		{float64(1788256800), "", time.Unix(1788256800, 0), true},
+		{float64(1788256800000), "", time.UnixMilli(1788256800000), true},
+		{"1788256800", "unix", time.Unix(1788256800, 0), true},
+		{"1788256800000", "unix_ms", time.UnixMilli(1788256800000), true},
+		{"yesterday", "", time.Time{}, false},
+		{"", "", time.Time{}, false},
+		{"soon", "unix", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseDateValue(tt.value, tt.format)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("parseDateValue(%v, %q) = %v, %v; want %v, %v", tt.value, tt.format, got, ok, tt.want, tt.ok)
+		}
+	}
+}
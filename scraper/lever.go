@@ -0,0 +1,157 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// defaultLeverBaseURL is the public Lever postings API
+const defaultLeverBaseURL = "https://api.lever.co/v0/postings"
+
+// leverPosting mirrors a single posting returned by the Lever postings API
+type leverPosting struct {
+	ID         string `json:"id"`
+	Text       string `json:"text"`
+	Categories struct {
+		Team         string   `json:"team"`
+		Department   string   `json:"department"`
+		Commitment   string   `json:"commitment"`
+		Location     string   `json:"location"`
+		AllLocations []string `json:"allLocations"`
+	} `json:"categories"`
+	WorkplaceType    string `json:"workplaceType"` // "remote", "hybrid", "onsite" or "unspecified"
+	HostedURL        string `json:"hostedUrl"`
+	ApplyURL         string `json:"applyUrl"`
+	CreatedAt        int64  `json:"createdAt"` // milliseconds since epoch
+	DescriptionPlain string `json:"descriptionPlain"`
+	SalaryRange      *struct {
+		Currency string  `json:"currency"`
+		Interval string  `json:"interval"`
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+	} `json:"salaryRange"`
+}
+
+func init() {
+	registerSource(sourceAdapter{
+		Type:     "lever_api",
+		Required: []string{"companies"},
+		Fetch:    (*APIScraper).scrapeLeverAPI,
+	})
+}
+
+// scrapeLeverAPI scrapes multiple Lever company boards
+func (as *APIScraper) scrapeLeverAPI(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
+	baseURL := apiSite.BaseURL
+	if baseURL == "" {
+		baseURL = defaultLeverBaseURL
+	}
+
+	return as.scrapeCompanies(ctx, apiSite.Companies, func(ctx context.Context, company string) ([]models.JobListing, error) {
+		return as.scrapeSingleLeverBoard(ctx, baseURL, company)
+	}), nil
+}
+
+// scrapeSingleLeverBoard scrapes a single Lever company board
+func (as *APIScraper) scrapeSingleLeverBoard(ctx context.Context, baseURL, company string) ([]models.JobListing, error) {
+	var jobs []models.JobListing
+
+	apiURL := fmt.Sprintf("%s/%s?mode=json", strings.TrimRight(baseURL, "/"), url.PathEscape(company))
+
+	var postings []leverPosting
+	if err := as.getJSON(ctx, apiURL, &postings); err != nil {
+		return jobs, err
+	}
+
+	for _, posting := range postings {
+		location := posting.Categories.Location
+		if location == "" && len(posting.Categories.AllLocations) > 0 {
+			location = strings.Join(posting.Categories.AllLocations, "; ")
+		}
+
+		description := strings.TrimSpace(posting.DescriptionPlain)
+
+		// Lever flags remote roles through workplaceType rather than the location text
+		if isRemoteJob(posting.Text, company, location, posting.WorkplaceType+" "+description) && isRelevantRole(posting.Text, description) {
+			department := posting.Categories.Team
+			if department == "" {
+				department = posting.Categories.Department
+			}
+
+			jobURL := posting.HostedURL
+			if jobURL == "" {
+				jobURL = posting.ApplyURL
+			}
+
+			jobListing := models.JobListing{
+				ID:             fmt.Sprintf("lever-%s-%s", company, posting.ID),
+				Title:          posting.Text,
+				Company:        strings.Title(company), // Capitalize company name
+				Location:       location,
+				URL:            jobURL,
+				Description:    description,
+				Source:         fmt.Sprintf("Lever - %s", strings.Title(company)),
+				Department:     department,
+				EmploymentType: posting.Categories.Commitment,
+				WorkplaceType:  normalizeWorkplaceType(posting.WorkplaceType),
+				ScrapedAt:      time.Now(),
+				PostedDate:     time.Now(),
+			}
+			if posting.CreatedAt > 0 {
+				jobListing.PostedDate = time.UnixMilli(posting.CreatedAt)
+			}
+			if posting.SalaryRange != nil {
+				jobListing.Salary = formatSalaryRange(posting.SalaryRange.Min, posting.SalaryRange.Max,
+					posting.SalaryRange.Currency, posting.SalaryRange.Interval)
+			}
+			jobs = append(jobs, jobListing)
+		}
+	}
+
+	return jobs, nil
+}
+
+// normalizeWorkplaceType maps the various ATS spellings onto "remote", "hybrid" or "onsite"
+func normalizeWorkplaceType(workplaceType string) string {
+	switch strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(workplaceType, "-", ""), "_", "")) {
+	case "remote":
+		return "remote"
+	case "hybrid":
+		return "hybrid"
+	case "onsite", "inoffice", "office":
+		return "onsite"
+	default:
+		return ""
+	}
+}
+
+// formatSalaryRange renders a min/max salary pair, e.g. "USD 120000 - 150000 per-year-salary"
+func formatSalaryRange(min, max float64, currency, interval string) string {
+	if min == 0 && max == 0 {
+		return ""
+	}
+
+	var salary string
+	switch {
+	case min > 0 && max > 0 && min != max:
+		salary = fmt.Sprintf("%.0f - %.0f", min, max)
+	case max > 0:
+		salary = fmt.Sprintf("%.0f", max)
+	default:
+		salary = fmt.Sprintf("%.0f", min)
+	}
+
+	if currency != "" {
+		salary = currency + " " + salary
+	}
+	if interval != "" {
+		salary += " " + interval
+	}
+	return salary
+}
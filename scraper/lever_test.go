@@ -0,0 +1,59 @@
+package scraper
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScrapeSingleLeverBoard(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/v0/postings/acme?mode=json": "lever/acme.json",
+	})
+	as := NewAPIScraper(testConfig())
+	baseURL := server.URL + "/v0/postings"
+
+	jobs, err := as.scrapeSingleLeverBoard(context.Background(), baseURL, "acme")
+	if err != nil {
+		t.Fatalf("scrapeSingleLeverBoard: %v", err)
+	}
+
+	// The office manager is neither remote nor relevant
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2: %+v", len(jobs), jobs)
+	}
+
+	platform := jobs[0]
+	if platform.ID != "lever-acme-a1b2c3" || platform.Title != "Senior Platform Engineer" || platform.Company != "Acme" {
+		t.Errorf("first job = %+v", platform)
+	}
+	if platform.URL != "https://jobs.lever.co/acme/a1b2c3" || platform.Location != "Remote - US" || platform.WorkplaceType != "remote" {
+		t.Errorf("first job URL = %q, location = %q, workplace = %q", platform.URL, platform.Location, platform.WorkplaceType)
+	}
+	if platform.Department != "Infrastructure" || platform.EmploymentType != "Full-time" {
+		t.Errorf("first job department = %q, employment type = %q", platform.Department, platform.EmploymentType)
+	}
+	if platform.Salary != "USD 150000 - 180000 per-year-salary" {
+		t.Errorf("first job salary = %q", platform.Salary)
+	}
+	if platform.Description != "Run our Kubernetes clusters." {
+		t.Errorf("first job description = %q", platform.Description)
+	}
+	if want := time.UnixMilli(1789574400000); !platform.PostedDate.Equal(want) {
+		t.Errorf("posted date = %v, want %v", platform.PostedDate, want)
+	}
+
+	// Only the description says this one is remote and relevant
+	tooling := jobs[1]
+	if tooling.ID != "lever-acme-d4e5f6" || tooling.Location != "Berlin; Lisbon" || tooling.Department != "Engineering" {
+		t.Errorf("second job = %+v", tooling)
+	}
+	if tooling.URL != "https://jobs.lever.co/acme/d4e5f6/apply" {
+		t.Errorf("second job should fall back to the apply URL, got %q", tooling.URL)
+	}
+
+	if _, err := as.scrapeSingleLeverBoard(context.Background(), baseURL, "missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("missing board: err = %v, want a 404", err)
+	}
+}
@@ -0,0 +1,209 @@
+package scraper
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"job-scraper/config"
+)
+
+// defaultMaxPages caps pagination when a site doesn't set max_pages
+const defaultMaxPages = 10
+
+// linkRelRegex matches a single entry of an RFC 5988 Link header
+var linkRelRegex = regexp.MustCompile(`<([^>]*)>\s*((?:;\s*[^;,]+)*)`)
+
+// paginator tracks the position of a generic API site while it is paged through
+type paginator struct {
+	cfg    config.Pagination
+	page   int
+	offset int
+	cursor string
+	pages  int
+}
+
+// newPaginator creates a paginator positioned on the first page
+func newPaginator(cfg config.Pagination) *paginator {
+	p := &paginator{cfg: cfg}
+	switch cfg.Type {
+	case "page":
+		p.page = 1
+		if cfg.Start != nil {
+			p.page = *cfg.Start
+		}
+	case "offset":
+		if cfg.Start != nil {
+			p.offset = *cfg.Start
+		}
+	}
+	return p
+}
+
+// maxPages returns the safety cap on the number of requests
+func (p *paginator) maxPages() int {
+	if p.cfg.Type == "" {
+		return 1
+	}
+	if p.cfg.MaxPages > 0 {
+		return p.cfg.MaxPages
+	}
+	return defaultMaxPages
+}
+
+// param returns the query parameter carrying the position, with a per-type default
+func (p *paginator) param() string {
+	if p.cfg.Param != "" {
+		return p.cfg.Param
+	}
+	switch p.cfg.Type {
+	case "offset":
+		return "offset"
+	case "cursor":
+		return "cursor"
+	default:
+		return "page"
+	}
+}
+
+// vars returns the current position for use in request body templates
+func (p *paginator) vars() map[string]interface{} {
+	return map[string]interface{}{
+		"page":   p.page,
+		"offset": p.offset,
+		"limit":  p.cfg.Limit,
+		"cursor": p.cursor,
+	}
+}
+
+// apply sets the pagination query parameters for the current position on reqURL
+func (p *paginator) apply(reqURL *url.URL) string {
+	if p.cfg.In == "body" {
+		return reqURL.String()
+	}
+
+	query := reqURL.Query()
+
+	switch p.cfg.Type {
+	case "page":
+		query.Set(p.param(), strconv.Itoa(p.page))
+	case "offset":
+		query.Set(p.param(), strconv.Itoa(p.offset))
+	case "cursor":
+		if p.cursor != "" {
+			query.Set(p.param(), p.cursor)
+		}
+	}
+	if p.cfg.LimitParam != "" && p.cfg.Limit > 0 {
+		query.Set(p.cfg.LimitParam, strconv.Itoa(p.cfg.Limit))
+	}
+
+	reqURL.RawQuery = query.Encode()
+	return reqURL.String()
+}
+
+// next advances to the following page and returns its URL, or "" once the results are exhausted.
+// itemCount is the number of items on the page that was just processed.
+func (p *paginator) next(current string, response interface{}, header http.Header, itemCount int) string {
+	p.pages++
+	if p.pages >= p.maxPages() || itemCount == 0 {
+		return ""
+	}
+
+	// A short page means there is nothing left to fetch
+	if (p.cfg.Type == "page" || p.cfg.Type == "offset") && p.cfg.Limit > 0 && itemCount < p.cfg.Limit {
+		return ""
+	}
+
+	currentURL, err := url.Parse(current)
+	if err != nil {
+		return ""
+	}
+
+	switch p.cfg.Type {
+	case "page":
+		p.page++
+	case "offset":
+		if p.cfg.Limit > 0 {
+			p.offset += p.cfg.Limit
+		} else {
+			p.offset += itemCount
+		}
+	case "cursor":
+		cursor := lookupString(response, p.cfg.CursorPath)
+		if cursor == "" || cursor == p.cursor {
+			return ""
+		}
+		p.cursor = cursor
+	case "link":
+		next, ok := parseLinkHeader(header.Values("Link"))["next"]
+		if !ok {
+			return ""
+		}
+		nextURL, err := currentURL.Parse(next)
+		if err != nil {
+			return ""
+		}
+		return nextURL.String()
+	default:
+		return ""
+	}
+
+	return p.apply(currentURL)
+}
+
+// parseLinkHeader parses RFC 5988 Link header values into a map of rel to URL
+func parseLinkHeader(values []string) map[string]string {
+	links := make(map[string]string)
+
+	for _, value := range values {
+		for _, match := range linkRelRegex.FindAllStringSubmatch(value, -1) {
+			for _, param := range strings.Split(match[2], ";") {
+				name, val, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || strings.ToLower(strings.TrimSpace(name)) != "rel" {
+					continue
+				}
+				// rel may hold several space separated relation types
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+					rel = strings.ToLower(rel)
+					if _, exists := links[rel]; !exists {
+						links[rel] = match[1]
+					}
+				}
+			}
+		}
+	}
+
+	return links
+}
+
+// htmlPageURL renders the URL template of an HTML list for a 1-based page number.
+// {{page}} counts from the configured start, {{start}} is the offset of the page's first result.
+func htmlPageURL(cfg config.HTMLPagination, page int) string {
+	first := cfg.Start
+	if first == 0 {
+		first = 1
+	}
+	step := cfg.Step
+	if step <= 0 {
+		step = 10
+	}
+
+	return strings.NewReplacer(
+		"{{page}}", strconv.Itoa(first+page-1),
+		"{{start}}", strconv.Itoa((page-1)*step),
+	).Replace(cfg.URLTemplate)
+}
+
+// htmlMaxPages returns the safety cap on the pages of an HTML list
+func htmlMaxPages(cfg config.HTMLPagination) int {
+	if cfg.NextSelector == "" && cfg.URLTemplate == "" {
+		return 1
+	}
+	if cfg.MaxPages > 0 {
+		return cfg.MaxPages
+	}
+	return defaultMaxPages
+}
@@ -0,0 +1,146 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"job-scraper/config"
+)
+
+func TestPaginatorStart(t *testing.T) {
+	zero, five := 0, 5
+	tests := []struct {
+		name  string
+		cfg   config.Pagination
+		first string
+		next  string
+	}{
+		{"page defaults to 1", config.Pagination{Type: "page"}, "page=1", "page=2"},
+		{"page can start at 0", config.Pagination{Type: "page", Start: &zero}, "page=0", "page=1"},
+		{"offset defaults to 0", config.Pagination{Type: "offset", Limit: 20, LimitParam: "limit"}, "limit=20&offset=0", "limit=20&offset=20"},
+		{"offset start", config.Pagination{Type: "offset", Start: &five, Param: "from"}, "from=5", "from=8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPaginator(tt.cfg)
+			reqURL, _ := url.Parse("https://example.com/jobs")
+
+			first := p.apply(reqURL)
+			if want := "https://example.com/jobs?" + tt.first; first != want {
+				t.Errorf("first page = %q, want %q", first, want)
+			}
+
+			// A full page of 20 (or 3 items without a limit) moves on
+			items := 20
+			if tt.cfg.Limit == 0 {
+				items = 3
+			}
+			if next, want := p.next(first, nil, nil, items), "https://example.com/jobs?"+tt.next; next != want {
+				t.Errorf("next page = %q, want %q", next, want)
+			}
+		})
+	}
+}
+
+func TestPaginatorStops(t *testing.T) {
+	p := newPaginator(config.Pagination{Type: "page", Limit: 10, MaxPages: 3})
+	if next := p.next("https://example.com/jobs?page=1", nil, nil, 4); next != "" {
+		t.Errorf("short page: next = %q, want none", next)
+	}
+
+	p = newPaginator(config.Pagination{Type: "page", MaxPages: 2})
+	if next := p.next("https://example.com/jobs?page=1", nil, nil, 10); next == "" {
+		t.Fatal("first page: want a next page")
+	}
+	if next := p.next("https://example.com/jobs?page=2", nil, nil, 10); next != "" {
+		t.Errorf("max_pages reached: next = %q, want none", next)
+	}
+}
+
+func TestHTMLPageURL(t *testing.T) {
+	tests := []struct {
+		cfg  config.HTMLPagination
+		page int
+		want string
+	}{
+		{config.HTMLPagination{URLTemplate: "/jobs?page={{page}}"}, 1, "/jobs?page=1"},
+		{config.HTMLPagination{URLTemplate: "/jobs?page={{page}}"}, 3, "/jobs?page=3"},
+		{config.HTMLPagination{URLTemplate: "/jobs/p{{page}}", Start: 2}, 2, "/jobs/p3"},
+		{config.HTMLPagination{URLTemplate: "/jobs?start={{start}}"}, 3, "/jobs?start=20"},
+		{config.HTMLPagination{URLTemplate: "/jobs?start={{start}}", Step: 25}, 2, "/jobs?start=25"},
+	}
+
+	for _, tt := range tests {
+		if got := htmlPageURL(tt.cfg, tt.page); got != tt.want {
+			t.Errorf("htmlPageURL(%+v, %d) = %q, want %q", tt.cfg, tt.page, got, tt.want)
+		}
+	}
+}
+
+func TestScrapeSiteURLTemplate(t *testing.T) {
+	// Page 3 repeats page 2, as boards do past their last page
+	pages := map[string]string{"1": "page1.html", "2": "page2.html", "3": "page2.html", "4": "page1.html"}
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		mu.Lock()
+		requested = append(requested, page)
+		mu.Unlock()
+
+		file, ok := pages[page]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", "html_board", file))
+		if err != nil {
+			t.Errorf("reading payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		pagination config.HTMLPagination
+		requested  string
+	}{
+		{"stops when a page has nothing new", config.HTMLPagination{URLTemplate: "?page={{page}}", StopWhenNoNew: true}, "1 2 3"},
+		{"max_pages", config.HTMLPagination{URLTemplate: "?page={{page}}", MaxPages: 4}, "1 2 3 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested = nil
+			js := NewJobScraper(testConfig())
+
+			result, err := js.ScrapeSite(context.Background(), config.Site{
+				Name:       "Board",
+				URL:        server.URL + "/jobs?page=1",
+				Selector:   "li.job",
+				Pagination: tt.pagination,
+			})
+			if err != nil {
+				t.Fatalf("ScrapeSite: %v", err)
+			}
+
+			if got := strings.Join(requested, " "); got != tt.requested {
+				t.Errorf("requested pages %s, want %s", got, tt.requested)
+			}
+			// Cards repeated on later pages are only counted once
+			if len(result.Jobs) != 2 {
+				t.Errorf("got %d jobs, want 2: %+v", len(result.Jobs), result.Jobs)
+			}
+		})
+	}
+}
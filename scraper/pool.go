@@ -0,0 +1,146 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	"job-scraper/config"
+)
+
+const (
+	// defaultConcurrency is used when the config doesn't set scraper.concurrency
+	defaultConcurrency = 4
+
+	// defaultPerHost is used when the config doesn't set scraper.per_host
+	defaultPerHost = 2
+)
+
+// concurrency returns the configured worker count, or the default
+func concurrency(cfg config.ScraperConfig) int {
+	if cfg.Concurrency > 0 {
+		return cfg.Concurrency
+	}
+	return defaultConcurrency
+}
+
+// runPool calls work for every index in [0, count) with at most workers calls running at once.
+// Indexes are handed out in order and no new work starts once ctx is cancelled.
+func runPool(ctx context.Context, workers, count int, work func(ctx context.Context, i int)) {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// Checked separately so a free slot doesn't win over a cancelled context
+		if ctx.Err() != nil {
+			noteInterrupted(ctx)
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			work(ctx, i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// limitTransport caps the number of requests in flight, overall and per host.
+// A slot is held until the response body is closed.
+type limitTransport struct {
+	base    http.RoundTripper
+	global  chan struct{}
+	perHost int
+
+	mu    sync.Mutex
+	hosts map[string]chan struct{}
+}
+
+// newLimitTransport wraps base with the limits of cfg
+func newLimitTransport(base http.RoundTripper, cfg config.ScraperConfig) *limitTransport {
+	perHost := cfg.PerHost
+	if perHost <= 0 {
+		perHost = defaultPerHost
+	}
+
+	return &limitTransport{
+		base:    base,
+		global:  make(chan struct{}, concurrency(cfg)),
+		perHost: perHost,
+		hosts:   make(map[string]chan struct{}),
+	}
+}
+
+// hostSlots returns the semaphore of a host, creating it on first use
+func (t *limitTransport) hostSlots(host string) chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	slots, ok := t.hosts[host]
+	if !ok {
+		slots = make(chan struct{}, t.perHost)
+		t.hosts[host] = slots
+	}
+	return slots
+}
+
+// RoundTrip waits for a free global and per-host slot before sending the request
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	host := t.hostSlots(req.URL.Host)
+
+	// Take the host slot first so requests queued on a busy host don't hold global slots
+	select {
+	case host <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case t.global <- struct{}{}:
+	case <-ctx.Done():
+		<-host
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			<-t.global
+			<-host
+		})
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseBody frees the transport slot of a response once its body is closed
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the body and releases the slot
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
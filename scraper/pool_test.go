@@ -0,0 +1,172 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"job-scraper/config"
+)
+
+// inFlight tracks how many calls are running at once and the highest count seen
+type inFlight struct {
+	current, peak int32
+}
+
+func (f *inFlight) enter() {
+	n := atomic.AddInt32(&f.current, 1)
+	for {
+		peak := atomic.LoadInt32(&f.peak)
+		if n <= peak || atomic.CompareAndSwapInt32(&f.peak, peak, n) {
+			return
+		}
+	}
+}
+
+func (f *inFlight) leave() { atomic.AddInt32(&f.current, -1) }
+
+func (f *inFlight) max() int { return int(atomic.LoadInt32(&f.peak)) }
+
+func TestRunPool(t *testing.T) {
+	var running inFlight
+	done := make([]bool, 10)
+
+	runPool(context.Background(), 3, len(done), func(ctx context.Context, i int) {
+		running.enter()
+		defer running.leave()
+		time.Sleep(5 * time.Millisecond)
+		done[i] = true
+	})
+
+	for i, ok := range done {
+		if !ok {
+			t.Errorf("index %d was not run", i)
+		}
+	}
+	if got := running.max(); got != 3 {
+		t.Errorf("%d calls ran at once, want 3", got)
+	}
+}
+
+func TestRunPoolStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var started []int
+	runPool(ctx, 1, 10, func(ctx context.Context, i int) {
+		mu.Lock()
+		started = append(started, i)
+		mu.Unlock()
+		if i == 1 {
+			cancel()
+		}
+	})
+
+	if fmt.Sprint(started) != "[0 1]" {
+		t.Errorf("started %v, want [0 1]", started)
+	}
+}
+
+func TestRunKeepsSourceOrder(t *testing.T) {
+	board, err := os.ReadFile("testdata/html_board/page1.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first source answers last
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(board)
+	}))
+	defer server.Close()
+
+	var sources []config.Source
+	for _, name := range []string{"Slow", "Fast", "Faster"} {
+		path := "/fast"
+		if name == "Slow" {
+			path = "/slow"
+		}
+		sources = append(sources, config.HTMLSource(config.Site{Name: name, URL: server.URL + path + "?" + name, Selector: "li.job"}))
+	}
+
+	js := NewJobScraper(testConfig())
+	run, err := js.Run(context.Background(), sources)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	for i, source := range sources {
+		if got := run.Results[i].Site; got != source.Name() {
+			t.Errorf("result %d is %q, want %q", i, got, source.Name())
+		}
+	}
+	// Duplicates are dropped from the later sources, so the slow first source keeps its jobs
+	if len(run.Results[0].Jobs) == 0 || run.Jobs[0].Source != "Slow" {
+		t.Errorf("the first source lost its jobs: %+v", run.Results[0])
+	}
+}
+
+func TestLimitTransport(t *testing.T) {
+	// Each server stands for a host; requests block until released so the caps are reached
+	release := make(chan struct{})
+	var total inFlight
+	hosts := make([]*inFlight, 2)
+	var urls []string
+	for i := range hosts {
+		host := &inFlight{}
+		hosts[i] = host
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			total.enter()
+			host.enter()
+			<-release
+			host.leave()
+			total.leave()
+		}))
+		defer server.Close()
+		urls = append(urls, server.URL)
+	}
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, config.ScraperConfig{Concurrency: 3, PerHost: 2})}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			resp, err := client.Get(url)
+			if err != nil {
+				t.Errorf("GET %s: %v", url, err)
+				return
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}(urls[i%2])
+	}
+
+	// Let the first requests reach the servers before releasing them one by one
+	time.Sleep(50 * time.Millisecond)
+	for i := 0; i < 8; i++ {
+		release <- struct{}{}
+		time.Sleep(5 * time.Millisecond)
+	}
+	wg.Wait()
+
+	if got := total.max(); got != 3 {
+		t.Errorf("%d requests in flight, want the concurrency cap of 3", got)
+	}
+	for i, host := range hosts {
+		if got := host.max(); got > 2 {
+			t.Errorf("host %d had %d requests in flight, want at most 2", i, got)
+		}
+	}
+}
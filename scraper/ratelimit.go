@@ -0,0 +1,154 @@
+package scraper
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"job-scraper/config"
+)
+
+// rateLimitKey carries a site's rate limit override in a request context
+type rateLimitKey struct{}
+
+// withRateLimit attaches a site's rate limit override to ctx; nil keeps the global limit
+func withRateLimit(ctx context.Context, limit *config.RateLimit) context.Context {
+	if limit == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, rateLimitKey{}, *limit)
+}
+
+// hostLimiter is a token bucket for a single host. Tokens may go negative:
+// each caller reserves its slot up front, so concurrent callers queue fairly.
+type hostLimiter struct {
+	mu       sync.Mutex
+	limit    config.RateLimit
+	override bool // Set once a site override replaced the global limit
+	tokens   float64
+	last     time.Time
+}
+
+// burst returns the bucket size
+func (l *hostLimiter) burst() float64 {
+	if l.limit.Burst > 0 {
+		return float64(l.limit.Burst)
+	}
+	return 1
+}
+
+// adopt applies a site override. The first override replaces the global limit;
+// when several sites share a host, the strictest rate wins. Tokens already spent
+// stay spent, so switching limits never hands out a fresh burst.
+func (l *hostLimiter) adopt(limit config.RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current := l.limit.RequestsPerSecond
+	stricter := limit.RequestsPerSecond > 0 && (current == 0 || limit.RequestsPerSecond < current)
+	if l.override && !stricter {
+		return
+	}
+
+	// Settle the bucket under the old rate before the new one applies. An unthrottled
+	// host never spends tokens, so its bucket counts as full.
+	if current > 0 {
+		l.refill(time.Now())
+	} else {
+		l.tokens = math.MaxFloat64
+		l.last = time.Now()
+	}
+
+	l.limit = limit
+	l.override = true
+	if l.tokens > l.burst() {
+		l.tokens = l.burst()
+	}
+}
+
+// refill adds the tokens earned since the last update, up to the bucket size
+func (l *hostLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.limit.RequestsPerSecond
+	if l.tokens > l.burst() {
+		l.tokens = l.burst()
+	}
+	l.last = now
+}
+
+// reserve takes a token and returns how long the caller must wait before sending
+func (l *hostLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var delay time.Duration
+	if rps := l.limit.RequestsPerSecond; rps > 0 {
+		l.refill(time.Now())
+
+		l.tokens--
+		if l.tokens < 0 {
+			delay = time.Duration(-l.tokens / rps * float64(time.Second))
+		}
+	}
+
+	if jitter := time.Duration(l.limit.Jitter); jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(jitter)))
+	}
+	return delay
+}
+
+// rateLimitTransport delays requests so each host sees at most its configured rate
+type rateLimitTransport struct {
+	base   http.RoundTripper
+	global config.RateLimit
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+// newRateLimitTransport wraps base with the global rate limit of cfg
+func newRateLimitTransport(base http.RoundTripper, cfg config.ScraperConfig) *rateLimitTransport {
+	return &rateLimitTransport{
+		base:   base,
+		global: cfg.RateLimit,
+		hosts:  make(map[string]*hostLimiter),
+	}
+}
+
+// limiter returns the limiter of a host, creating it with the global limit on first use
+func (t *rateLimitTransport) limiter(host string) *hostLimiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.hosts[host]
+	if !ok {
+		l = &hostLimiter{limit: t.global, last: time.Now()}
+		l.tokens = l.burst()
+		t.hosts[host] = l
+	}
+	return l
+}
+
+// RoundTrip waits for the host's next free slot before sending the request
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	l := t.limiter(req.URL.Host)
+	if limit, ok := ctx.Value(rateLimitKey{}).(config.RateLimit); ok {
+		l.adopt(limit)
+	}
+
+	if delay := l.reserve(); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,131 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"job-scraper/config"
+)
+
+// newHostLimiter returns the limiter a transport with the given global limit creates for a new host
+func newHostLimiter(limit config.RateLimit) *hostLimiter {
+	return newRateLimitTransport(nil, config.ScraperConfig{RateLimit: limit}).limiter("jobs.example.com")
+}
+
+func TestHostLimiterBurst(t *testing.T) {
+	l := newHostLimiter(config.RateLimit{RequestsPerSecond: 10, Burst: 3})
+
+	for i := 0; i < 3; i++ {
+		if delay := l.reserve(); delay != 0 {
+			t.Errorf("request %d delayed %v, want none within the burst", i+1, delay)
+		}
+	}
+	// The bucket is empty, so the next request waits for a token at 10 per second
+	if delay := l.reserve(); delay < 90*time.Millisecond || delay > 100*time.Millisecond {
+		t.Errorf("request 4 delayed %v, want about 100ms", delay)
+	}
+	if delay := l.reserve(); delay < 190*time.Millisecond || delay > 200*time.Millisecond {
+		t.Errorf("request 5 delayed %v, want about 200ms", delay)
+	}
+}
+
+func TestHostLimiterAdopt(t *testing.T) {
+	t.Run("stricter rate wins", func(t *testing.T) {
+		l := newHostLimiter(config.RateLimit{})
+
+		l.adopt(config.RateLimit{RequestsPerSecond: 10})
+		l.adopt(config.RateLimit{RequestsPerSecond: 2})
+		l.adopt(config.RateLimit{RequestsPerSecond: 5})
+		l.adopt(config.RateLimit{})
+
+		if got := l.limit.RequestsPerSecond; got != 2 {
+			t.Errorf("rate = %v, want the strictest 2", got)
+		}
+	})
+
+	t.Run("first override replaces a looser global limit", func(t *testing.T) {
+		l := newHostLimiter(config.RateLimit{RequestsPerSecond: 1})
+		l.adopt(config.RateLimit{RequestsPerSecond: 5, Burst: 2})
+
+		if got := l.limit.RequestsPerSecond; got != 5 {
+			t.Errorf("rate = %v, want the override's 5", got)
+		}
+	})
+
+	t.Run("unthrottled host starts with the override's burst", func(t *testing.T) {
+		l := newHostLimiter(config.RateLimit{})
+		l.adopt(config.RateLimit{RequestsPerSecond: 1, Burst: 3})
+
+		for i := 0; i < 3; i++ {
+			if delay := l.reserve(); delay != 0 {
+				t.Errorf("request %d delayed %v, want none within the burst", i+1, delay)
+			}
+		}
+	})
+
+	t.Run("spent tokens stay spent", func(t *testing.T) {
+		l := newHostLimiter(config.RateLimit{RequestsPerSecond: 1, Burst: 3})
+		for i := 0; i < 3; i++ {
+			l.reserve()
+		}
+
+		l.adopt(config.RateLimit{RequestsPerSecond: 1, Burst: 3})
+		if delay := l.reserve(); delay < 900*time.Millisecond {
+			t.Errorf("delay after adopting = %v, want the bucket to stay empty", delay)
+		}
+	})
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 127.0.0.1 and localhost reach the same server but are throttled as separate hosts
+	hosts := []string{server.URL, "http://localhost:" + serverURL.Port()}
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, config.ScraperConfig{
+		RateLimit: config.RateLimit{RequestsPerSecond: 20},
+	})}
+
+	get := func(ctx context.Context, target string) time.Duration {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s: %v", target, err)
+		}
+		resp.Body.Close()
+		return time.Since(start)
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		get(context.Background(), hosts[0])
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 requests to one host took %v, want at least 100ms at 20 per second", elapsed)
+	}
+
+	// The other host has its own bucket
+	if elapsed := get(context.Background(), hosts[1]); elapsed > 40*time.Millisecond {
+		t.Errorf("first request to another host took %v, want no delay", elapsed)
+	}
+
+	// A site override slows its own requests down
+	ctx := withRateLimit(context.Background(), &config.RateLimit{RequestsPerSecond: 5})
+	get(ctx, hosts[1])
+	if elapsed := get(ctx, hosts[1]); elapsed < 150*time.Millisecond {
+		t.Errorf("request under a 5 per second override took %v, want about 200ms", elapsed)
+	}
+}
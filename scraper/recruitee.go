@@ -0,0 +1,152 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// recruiteeTimeLayout is the timestamp format used by the Recruitee careers API
+const recruiteeTimeLayout = "2006-01-02 15:04:05 MST"
+
+// recruiteeOffer mirrors a single offer of the Recruitee careers API
+type recruiteeOffer struct {
+	ID                 int    `json:"id"`
+	Slug               string `json:"slug"`
+	Title              string `json:"title"`
+	Description        string `json:"description"`  // HTML
+	Requirements       string `json:"requirements"` // HTML
+	Location           string `json:"location"`
+	City               string `json:"city"`
+	Country            string `json:"country"`
+	Remote             bool   `json:"remote"`
+	Hybrid             bool   `json:"hybrid"`
+	OnSite             bool   `json:"on_site"`
+	CareersURL         string `json:"careers_url"`
+	Department         string `json:"department"`
+	EmploymentTypeCode string `json:"employment_type_code"`
+	PublishedAt        string `json:"published_at"`
+	CompanyName        string `json:"company_name"`
+	Salary             *struct {
+		Min      interface{} `json:"min"` // a number, a numeric string, "" or null
+		Max      interface{} `json:"max"`
+		Currency string      `json:"currency"`
+		Period   string      `json:"period"`
+	} `json:"salary"`
+}
+
+func init() {
+	registerSource(sourceAdapter{
+		Type:     "recruitee_api",
+		Required: []string{"companies"},
+		Fetch:    (*APIScraper).scrapeRecruiteeAPI,
+	})
+}
+
+// scrapeRecruiteeAPI scrapes multiple Recruitee company career sites
+func (as *APIScraper) scrapeRecruiteeAPI(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
+	return as.scrapeCompanies(ctx, apiSite.Companies, func(ctx context.Context, company string) ([]models.JobListing, error) {
+		return as.scrapeSingleRecruiteeSite(ctx, apiSite.BaseURL, company)
+	}), nil
+}
+
+// scrapeSingleRecruiteeSite scrapes a single Recruitee career site.
+// The offers endpoint is not paginated; it returns every published offer at once.
+func (as *APIScraper) scrapeSingleRecruiteeSite(ctx context.Context, baseURL, company string) ([]models.JobListing, error) {
+	var jobs []models.JobListing
+
+	// Each company lives on its own subdomain unless a base URL overrides it
+	apiURL := fmt.Sprintf("https://%s.recruitee.com/api/offers/", url.PathEscape(company))
+	if baseURL != "" {
+		apiURL = fmt.Sprintf("%s/%s/api/offers/", strings.TrimRight(baseURL, "/"), url.PathEscape(company))
+	}
+
+	var response struct {
+		Offers []recruiteeOffer `json:"offers"`
+	}
+	if err := as.getJSON(ctx, apiURL, &response); err != nil {
+		return jobs, err
+	}
+
+	for _, offer := range response.Offers {
+		location := offer.Location
+		if location == "" {
+			location = joinNonEmpty(", ", offer.City, offer.Country)
+		}
+
+		workplaceType := ""
+		switch {
+		case offer.Remote:
+			workplaceType = "remote"
+		case offer.Hybrid:
+			workplaceType = "hybrid"
+		case offer.OnSite:
+			workplaceType = "onsite"
+		}
+
+		description := htmlToText(offer.Description)
+		if requirements := htmlToText(offer.Requirements); requirements != "" {
+			description += "\n\n" + requirements
+		}
+		description = strings.TrimSpace(description)
+
+		if isRemoteJob(offer.Title, company, location, workplaceType+" "+description) && isRelevantRole(offer.Title, description) {
+			companyName := offer.CompanyName
+			if companyName == "" {
+				companyName = strings.Title(company) // Capitalize company name
+			}
+
+			jobListing := models.JobListing{
+				ID:             fmt.Sprintf("recruitee-%s-%d", company, offer.ID),
+				Title:          offer.Title,
+				Company:        companyName,
+				Location:       location,
+				URL:            offer.CareersURL,
+				Description:    description,
+				Source:         fmt.Sprintf("Recruitee - %s", companyName),
+				Department:     offer.Department,
+				EmploymentType: recruiteeEmploymentType(offer.EmploymentTypeCode),
+				WorkplaceType:  workplaceType,
+				ScrapedAt:      time.Now(),
+				PostedDate:     time.Now(),
+			}
+			if posted, err := time.Parse(recruiteeTimeLayout, offer.PublishedAt); err == nil {
+				jobListing.PostedDate = posted
+			}
+			if offer.Salary != nil {
+				min, max := valueToString(offer.Salary.Min), valueToString(offer.Salary.Max)
+				salary := joinNonEmpty(" - ", min, max)
+				if min == max {
+					salary = min
+				}
+				if salary != "" {
+					jobListing.Salary = joinNonEmpty(" ", offer.Salary.Currency, salary, offer.Salary.Period)
+				}
+			}
+			jobs = append(jobs, jobListing)
+		}
+	}
+
+	return jobs, nil
+}
+
+// recruiteeEmploymentType turns Recruitee's employment type codes into readable labels
+func recruiteeEmploymentType(code string) string {
+	switch code {
+	case "fulltime", "fulltime_permanent", "fulltime_fixed_term":
+		return "Full-time"
+	case "parttime", "parttime_permanent", "parttime_fixed_term":
+		return "Part-time"
+	case "contract", "freelance":
+		return "Contract"
+	case "internship":
+		return "Intern"
+	default:
+		return code
+	}
+}
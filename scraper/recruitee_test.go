@@ -0,0 +1,48 @@
+package scraper
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScrapeSingleRecruiteeSite(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/acme/api/offers/": "recruitee/acme.json",
+	})
+	as := NewAPIScraper(testConfig())
+
+	jobs, err := as.scrapeSingleRecruiteeSite(context.Background(), server.URL, "acme")
+	if err != nil {
+		t.Fatalf("scrapeSingleRecruiteeSite: %v", err)
+	}
+
+	// The office manager is neither remote nor relevant
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2: %+v", len(jobs), jobs)
+	}
+
+	devops := jobs[0]
+	if devops.ID != "recruitee-acme-501" || devops.Company != "Acme B.V." || devops.Location != "Amsterdam, Netherlands" {
+		t.Errorf("first job = %+v", devops)
+	}
+	if devops.Salary != "EUR 70000 - 90000 year" || devops.EmploymentType != "Full-time" || devops.WorkplaceType != "remote" {
+		t.Errorf("first job salary = %q, employment type = %q, workplace = %q", devops.Salary, devops.EmploymentType, devops.WorkplaceType)
+	}
+	if !strings.Contains(devops.Description, "Own our CI/CD pipelines.") || !strings.Contains(devops.Description, "Five years of Linux") {
+		t.Errorf("description should hold the plain text description and requirements, got %q", devops.Description)
+	}
+	if want := time.Date(2026, 9, 5, 12, 0, 0, 0, time.UTC); !devops.PostedDate.Equal(want) {
+		t.Errorf("posted date = %v, want %v", devops.PostedDate, want)
+	}
+
+	// Empty salary bounds must not drop the board; the job simply has no salary
+	tooling := jobs[1]
+	if tooling.ID != "recruitee-acme-502" || tooling.Company != "Acme" || tooling.Location != "Utrecht, Netherlands" {
+		t.Errorf("second job = %+v", tooling)
+	}
+	if tooling.Salary != "" || tooling.EmploymentType != "Contract" {
+		t.Errorf("second job salary = %q, employment type = %q", tooling.Salary, tooling.EmploymentType)
+	}
+}
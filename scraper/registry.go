@@ -0,0 +1,113 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// sourceAdapter describes an API site type. Each adapter registers itself from an init
+// function in its own file, so adding a board never touches the dispatch code.
+type sourceAdapter struct {
+	// Type is the api_sites "type" value handled by the adapter
+	Type string
+
+	// Required lists the config keys (JSON names) that must be set, e.g. "companies"
+	Required []string
+
+	// Validate checks the rest of the site's config before any request is sent; optional
+	Validate func(apiSite config.APISite) error
+
+	// Fetch scrapes the site
+	Fetch func(as *APIScraper, ctx context.Context, apiSite config.APISite) ([]models.JobListing, error)
+}
+
+// sourceAdapters holds the registered adapters by type
+var sourceAdapters = make(map[string]sourceAdapter)
+
+// registerSource adds an adapter. Registering a type twice is a programming error and panics.
+func registerSource(adapter sourceAdapter) {
+	if adapter.Type == "" || adapter.Fetch == nil {
+		panic("scraper: source adapter needs a type and a fetch function")
+	}
+	if _, exists := sourceAdapters[adapter.Type]; exists {
+		panic(fmt.Sprintf("scraper: source type %q registered twice", adapter.Type))
+	}
+	sourceAdapters[adapter.Type] = adapter
+}
+
+// sourceTypes returns the registered types in alphabetical order
+func sourceTypes() []string {
+	types := make([]string, 0, len(sourceAdapters))
+	for name := range sourceAdapters {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// lookupSource returns the adapter of an API site, or an error naming the known types
+func lookupSource(apiSite config.APISite) (sourceAdapter, error) {
+	adapter, ok := sourceAdapters[apiSite.Type]
+	if !ok {
+		return sourceAdapter{}, fmt.Errorf("api site %s has unknown type %q (known types: %s)",
+			apiSite.Name, apiSite.Type, strings.Join(sourceTypes(), ", "))
+	}
+	return adapter, nil
+}
+
+// validate checks the required keys and the adapter's own rules
+func (a sourceAdapter) validate(apiSite config.APISite) error {
+	if len(a.Required) > 0 {
+		data, err := json.Marshal(apiSite)
+		if err != nil {
+			return err
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+
+		for _, key := range a.Required {
+			if valueToString(fields[key]) == "" {
+				return fmt.Errorf("api site %s of type %s requires %q", apiSite.Name, a.Type, key)
+			}
+		}
+	}
+
+	if a.Validate != nil {
+		if err := a.Validate(apiSite); err != nil {
+			return fmt.Errorf("api site %s: %v", apiSite.Name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateConfig checks every API source against its adapter, so unknown types and
+// incomplete sites fail before the run starts. All problems are reported together.
+func ValidateConfig(cfg *config.Config) error {
+	var problems []string
+	for _, source := range cfg.AllSources() {
+		if source.Kind != config.SourceAPI {
+			continue
+		}
+
+		adapter, err := lookupSource(*source.APISite)
+		if err == nil {
+			err = adapter.validate(*source.APISite)
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid api sites:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
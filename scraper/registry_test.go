@@ -0,0 +1,112 @@
+package scraper
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []config.Source
+		want    []string // substrings of the error; none means valid
+	}{
+		{
+			name: "valid",
+			sources: []config.Source{
+				config.HTMLSource(config.Site{Name: "Board", URL: "https://example.com/jobs", Selector: "li.job"}),
+				config.APISource(config.APISite{Name: "Greenhouse", Type: "greenhouse_api", BaseURL: "https://boards-api.greenhouse.io/v1/boards", Companies: []string{"acme"}}),
+			},
+		},
+		{
+			name: "unknown type",
+			sources: []config.Source{
+				config.APISource(config.APISite{Name: "Mystery", Type: "mystery_api", URL: "https://example.com"}),
+			},
+			want: []string{`api site Mystery has unknown type "mystery_api"`, "greenhouse_api"},
+		},
+		{
+			name: "missing required key",
+			sources: []config.Source{
+				config.APISource(config.APISite{Name: "Greenhouse", Type: "greenhouse_api", Companies: []string{"acme"}}),
+			},
+			want: []string{`api site Greenhouse of type greenhouse_api requires "base_url"`},
+		},
+		{
+			name: "adapter validation",
+			sources: []config.Source{
+				config.APISource(config.APISite{Name: "Jobs GraphQL", Type: "graphql", URL: "https://example.com/graphql"}),
+			},
+			want: []string{"api site Jobs GraphQL: graphql requires a query or query_file"},
+		},
+		{
+			name: "malformed field path",
+			sources: []config.Source{
+				config.APISource(config.APISite{Name: "Broken", Type: "api", URL: "https://example.com/jobs", Fields: config.FieldMapping{Title: "title", Company: "company.name|org[", URL: `links["apply`}}),
+			},
+			want: []string{"api site Broken: invalid fields.company: unclosed bracket"},
+		},
+		{
+			name: "literal alternatives are not paths",
+			sources: []config.Source{
+				config.APISource(config.APISite{Name: "Literal", Type: "api", URL: "https://example.com/jobs", Fields: config.FieldMapping{Company: "company|'Acme [EU]'"}}),
+			},
+		},
+		{
+			name: "all problems together",
+			sources: []config.Source{
+				config.APISource(config.APISite{Name: "Mystery", Type: "mystery_api"}),
+				config.APISource(config.APISite{Name: "Greenhouse", Type: "greenhouse_api", BaseURL: "https://boards-api.greenhouse.io/v1/boards"}),
+				config.APISource(config.APISite{Name: "Paged", Type: "api", URL: "https://example.com/jobs", Pagination: config.Pagination{Type: "cursor"}}),
+			},
+			want: []string{
+				`api site Mystery has unknown type "mystery_api"`,
+				`api site Greenhouse of type greenhouse_api requires "companies"`,
+				"api site Paged: cursor pagination requires cursor_path",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfig(&config.Config{Sources: tt.sources})
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateConfig: %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("ValidateConfig succeeded, want an error containing %q", tt.want)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterSourceTwicePanics(t *testing.T) {
+	adapter := sourceAdapter{
+		Type: "test_duplicate",
+		Fetch: func(as *APIScraper, ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
+			return nil, nil
+		},
+	}
+	registerSource(adapter)
+	defer delete(sourceAdapters, adapter.Type)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a type twice should panic")
+		}
+	}()
+	registerSource(adapter)
+}
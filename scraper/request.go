@@ -0,0 +1,304 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"job-scraper/config"
+)
+
+var (
+	// envVarRegex matches ${VAR} references in config values
+	envVarRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+	// placeholderRegex matches {{name}} pagination placeholders in body templates
+	placeholderRegex = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+)
+
+// expandEnv replaces ${VAR} references with the value of the environment variable.
+// Unlike os.ExpandEnv it leaves bare $ signs alone, which are common in query strings.
+func expandEnv(value string) string {
+	return envVarRegex.ReplaceAllStringFunc(value, func(match string) string {
+		return os.Getenv(envVarRegex.FindStringSubmatch(match)[1])
+	})
+}
+
+// apiRequest describes a request of the API scraper
+type apiRequest struct {
+	method      string // defaults to GET
+	url         string
+	body        io.Reader
+	contentType string
+	accept      string            // defaults to application/json
+	headers     map[string]string // custom headers; ${VAR} references are expanded
+	auth        config.AuthConfig
+}
+
+// sendRequest sends r with the scraper's user agent, custom headers and credentials and returns
+// the response body and headers. Any status but 200 is an error.
+func (as *APIScraper) sendRequest(ctx context.Context, r apiRequest) ([]byte, http.Header, error) {
+	method := r.method
+	if method == "" {
+		method = "GET"
+	}
+
+	req, err := http.NewRequestWithContext(withAuthSecrets(ctx, r.auth), method, r.url, r.body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accept := r.accept
+	if accept == "" {
+		accept = "application/json"
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Accept", accept)
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	for key, value := range r.headers {
+		req.Header.Set(key, expandEnv(value))
+	}
+	if err := applyAuth(req, r.auth); err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := as.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("API returned status %d for %s", resp.StatusCode, r.url)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, resp.Header, nil
+}
+
+// buildRequestBody renders the site's body template for the current page.
+// It returns a nil reader when the site has no body.
+func buildRequestBody(apiSite config.APISite, pageVars map[string]interface{}) (io.Reader, string, error) {
+	if len(apiSite.Body) == 0 || string(apiSite.Body) == "null" {
+		return nil, "", nil
+	}
+
+	var template interface{}
+	if err := json.Unmarshal(apiSite.Body, &template); err != nil {
+		return nil, "", fmt.Errorf("invalid body template: %v", err)
+	}
+	rendered := renderTemplateValue(template, pageVars)
+
+	switch apiSite.BodyType {
+	case "", "json":
+		data, err := json.Marshal(rendered)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode request body: %v", err)
+		}
+		return bytes.NewReader(data), "application/json", nil
+	case "form":
+		fields, ok := rendered.(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("form body must be a JSON object")
+		}
+		form := url.Values{}
+		for key, value := range fields {
+			form.Set(key, valueToString(value))
+		}
+		return strings.NewReader(form.Encode()), "application/x-www-form-urlencoded", nil
+	default:
+		return nil, "", fmt.Errorf("unknown body_type %q", apiSite.BodyType)
+	}
+}
+
+// renderTemplateValue substitutes environment variables and pagination placeholders
+// in every string of a decoded JSON template. A string that is exactly one placeholder,
+// such as "{{offset}}", is replaced by the typed value so numbers stay numbers.
+func renderTemplateValue(value interface{}, pageVars map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if match := placeholderRegex.FindStringSubmatch(v); match != nil && match[0] == v {
+			if pageValue, ok := pageVars[match[1]]; ok {
+				return pageValue
+			}
+		}
+		rendered := placeholderRegex.ReplaceAllStringFunc(v, func(placeholder string) string {
+			name := placeholderRegex.FindStringSubmatch(placeholder)[1]
+			if pageValue, ok := pageVars[name]; ok {
+				return fmt.Sprint(pageValue)
+			}
+			return placeholder
+		})
+		return expandEnv(rendered)
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			rendered[key] = renderTemplateValue(child, pageVars)
+		}
+		return rendered
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, child := range v {
+			rendered[i] = renderTemplateValue(child, pageVars)
+		}
+		return rendered
+	default:
+		return v
+	}
+}
+
+// applyAuth adds the configured credentials to req
+func applyAuth(req *http.Request, auth config.AuthConfig) error {
+	switch auth.Type {
+	case "":
+		return nil
+	case "bearer":
+		token, err := requireEnv(auth.TokenEnv)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case "api_key":
+		key, err := requireEnv(auth.TokenEnv)
+		if err != nil {
+			return err
+		}
+		if name, ok := queryAuthParam(auth); ok {
+			query := req.URL.Query()
+			query.Set(name, key)
+			req.URL.RawQuery = query.Encode()
+		} else {
+			name := auth.Name
+			if name == "" {
+				name = "X-API-Key"
+			}
+			req.Header.Set(name, key)
+		}
+	case "basic":
+		username, err := requireEnv(auth.UsernameEnv)
+		if err != nil {
+			return err
+		}
+		// Some APIs (e.g. Reed) use the key as username with an empty password
+		password := ""
+		if auth.PasswordEnv != "" {
+			password = os.Getenv(auth.PasswordEnv)
+		}
+		req.SetBasicAuth(username, password)
+	default:
+		return fmt.Errorf("unknown auth type %q", auth.Type)
+	}
+
+	return nil
+}
+
+// queryAuthParam returns the query parameter an API key is sent in, if auth puts it in the query
+func queryAuthParam(auth config.AuthConfig) (string, bool) {
+	if auth.Type != "api_key" || auth.In != "query" {
+		return "", false
+	}
+	if auth.Name == "" {
+		return "api_key", true
+	}
+	return auth.Name, true
+}
+
+// secretsKey carries the credentials of the requests sent with a context
+type secretsKey struct{}
+
+// requestSecrets are credentials that transports writing requests to disk must redact
+type requestSecrets struct {
+	params []string // Query parameters holding a credential
+	values []string // Credential values, wherever they appear in the query or body
+}
+
+// withAuthSecrets marks the credentials of auth as secret for the requests sent with ctx:
+// the query parameter an API key is sent in, and the value of token_env
+func withAuthSecrets(ctx context.Context, auth config.AuthConfig) context.Context {
+	var secrets requestSecrets
+	if name, ok := queryAuthParam(auth); ok {
+		secrets.params = append(secrets.params, name)
+	}
+	if auth.TokenEnv != "" {
+		if value := os.Getenv(auth.TokenEnv); value != "" {
+			secrets.values = append(secrets.values, value)
+		}
+	}
+	if len(secrets.params) == 0 && len(secrets.values) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, secretsKey{}, secrets)
+}
+
+// redactedURL returns the URL of req with credentials in the query replaced by REDACTED.
+// URLs without credentials are returned unchanged.
+func redactedURL(req *http.Request) string {
+	secrets, ok := req.Context().Value(secretsKey{}).(requestSecrets)
+	if !ok {
+		return req.URL.String()
+	}
+
+	query := req.URL.Query()
+	redacted := false
+	for name, values := range query {
+		for i, value := range values {
+			if value != "" && (containsString(secrets.params, name) || containsString(secrets.values, value)) {
+				values[i] = "REDACTED"
+				redacted = true
+			}
+		}
+	}
+	if !redacted {
+		return req.URL.String()
+	}
+
+	u := *req.URL
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// redactedBody returns a request body with the credential values of ctx replaced by REDACTED
+func redactedBody(ctx context.Context, body []byte) []byte {
+	secrets, ok := ctx.Value(secretsKey{}).(requestSecrets)
+	if !ok {
+		return body
+	}
+	for _, value := range secrets.values {
+		body = bytes.ReplaceAll(body, []byte(value), []byte("REDACTED"))
+	}
+	return body
+}
+
+// containsString reports whether list holds s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// requireEnv returns the value of the named environment variable, failing when it is unset or empty
+func requireEnv(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("auth requires the name of an environment variable")
+	}
+	value := os.Getenv(name)
+	if value == "" {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
@@ -0,0 +1,177 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"job-scraper/config"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("JOBS_TEAM", "platform")
+
+	if got, want := expandEnv("team=${JOBS_TEAM}&price=$5&missing=${JOBS_MISSING}"), "team=platform&price=$5&missing="; got != want {
+		t.Errorf("expandEnv = %q, want %q", got, want)
+	}
+}
+
+func TestBuildRequestBody(t *testing.T) {
+	t.Setenv("JOBS_TEAM", "platform")
+	pageVars := map[string]interface{}{"offset": 20, "limit": 10}
+
+	tests := []struct {
+		name        string
+		body        string
+		bodyType    string
+		want        string
+		contentType string
+	}{
+		{"json keeps placeholder types", `{"from": "{{offset}}", "query": {"team": "${JOBS_TEAM}", "tags": ["{{limit}} per page"]}}`, "",
+			`{"from":20,"query":{"tags":["10 per page"],"team":"platform"}}`, "application/json"},
+		{"unknown placeholders are kept", `{"cursor": "{{cursor}}"}`, "json", `{"cursor":"{{cursor}}"}`, "application/json"},
+		{"form", `{"start": "{{offset}}", "team": "${JOBS_TEAM}"}`, "form", "start=20&team=platform", "application/x-www-form-urlencoded"},
+		{"no body", ``, "", "", ""},
+		{"null body", `null`, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType, err := buildRequestBody(config.APISite{Body: json.RawMessage(tt.body), BodyType: tt.bodyType}, pageVars)
+			if err != nil {
+				t.Fatalf("buildRequestBody: %v", err)
+			}
+
+			var got string
+			if body != nil {
+				data, _ := io.ReadAll(body)
+				got = string(data)
+			}
+			if got != tt.want || contentType != tt.contentType {
+				t.Errorf("body = %q (%s), want %q (%s)", got, contentType, tt.want, tt.contentType)
+			}
+		})
+	}
+
+	for _, site := range []config.APISite{
+		{Body: json.RawMessage(`["a"]`), BodyType: "form"},
+		{Body: json.RawMessage(`{}`), BodyType: "xml"},
+		{Body: json.RawMessage(`{`)},
+	} {
+		if _, _, err := buildRequestBody(site, pageVars); err == nil {
+			t.Errorf("body %s as %q: want an error", site.Body, site.BodyType)
+		}
+	}
+}
+
+func TestApplyAuth(t *testing.T) {
+	t.Setenv("JOBS_TOKEN", "s3cret")
+	t.Setenv("JOBS_USER", "reader")
+
+	tests := []struct {
+		name   string
+		auth   config.AuthConfig
+		header string // Header that should carry the credential
+		want   string
+		query  string
+	}{
+		{"none", config.AuthConfig{}, "Authorization", "", ""},
+		{"bearer", config.AuthConfig{Type: "bearer", TokenEnv: "JOBS_TOKEN"}, "Authorization", "Bearer s3cret", ""},
+		{"api key header", config.AuthConfig{Type: "api_key", TokenEnv: "JOBS_TOKEN"}, "X-API-Key", "s3cret", ""},
+		{"named api key header", config.AuthConfig{Type: "api_key", TokenEnv: "JOBS_TOKEN", Name: "X-Token"}, "X-Token", "s3cret", ""},
+		{"api key in query", config.AuthConfig{Type: "api_key", TokenEnv: "JOBS_TOKEN", In: "query"}, "X-API-Key", "", "api_key=s3cret&page=1"},
+		// Reed sends the key as the username with an empty password
+		{"basic without password", config.AuthConfig{Type: "basic", UsernameEnv: "JOBS_USER"}, "Authorization", "Basic cmVhZGVyOg==", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "https://api.example.com/jobs?page=1", nil)
+			if err := applyAuth(req, tt.auth); err != nil {
+				t.Fatalf("applyAuth: %v", err)
+			}
+			if got := req.Header.Get(tt.header); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.header, got, tt.want)
+			}
+			if tt.query != "" && req.URL.RawQuery != tt.query {
+				t.Errorf("query = %q, want %q", req.URL.RawQuery, tt.query)
+			}
+		})
+	}
+
+	for _, auth := range []config.AuthConfig{
+		{Type: "bearer"},
+		{Type: "bearer", TokenEnv: "JOBS_UNSET"},
+		{Type: "basic", UsernameEnv: "JOBS_UNSET"},
+		{Type: "oauth", TokenEnv: "JOBS_TOKEN"},
+	} {
+		req, _ := http.NewRequest("GET", "https://api.example.com/jobs", nil)
+		if err := applyAuth(req, auth); err == nil {
+			t.Errorf("auth %+v: want an error", auth)
+		}
+	}
+}
+
+func TestSendRequest(t *testing.T) {
+	t.Setenv("JOBS_TOKEN", "s3cret")
+
+	var got *http.Request
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got, gotBody = r, string(body)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"jobs": []}`))
+	}))
+	defer server.Close()
+
+	as := NewJobScraper(testConfig()).apiScraper
+	site := config.APISite{
+		Method:  "POST",
+		Headers: map[string]string{"X-Client": "job-scraper", "X-Token-Copy": "${JOBS_TOKEN}"},
+		Body:    json.RawMessage(`{"limit": "{{limit}}"}`),
+		Auth:    config.AuthConfig{Type: "bearer", TokenEnv: "JOBS_TOKEN"},
+	}
+	body, contentType, err := buildRequestBody(site, map[string]interface{}{"limit": 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _, err := as.sendRequest(context.Background(), apiRequest{
+		method:      site.Method,
+		url:         server.URL + "/jobs",
+		body:        body,
+		contentType: contentType,
+		headers:     site.Headers,
+		auth:        site.Auth,
+	})
+	if err != nil {
+		t.Fatalf("sendRequest: %v", err)
+	}
+	if string(data) != `{"jobs": []}` {
+		t.Errorf("response body = %q", data)
+	}
+
+	if got.Method != "POST" || gotBody != `{"limit":50}` || got.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("sent %s %q as %s, want the rendered JSON body", got.Method, gotBody, got.Header.Get("Content-Type"))
+	}
+	if got.Header.Get("X-Client") != "job-scraper" || got.Header.Get("X-Token-Copy") != "s3cret" {
+		t.Errorf("custom headers = %v", got.Header)
+	}
+	if got.Header.Get("Authorization") != "Bearer s3cret" || got.Header.Get("Accept") != "application/json" {
+		t.Errorf("Authorization = %q, Accept = %q", got.Header.Get("Authorization"), got.Header.Get("Accept"))
+	}
+
+	if _, _, err := as.sendRequest(context.Background(), apiRequest{url: server.URL + "/missing"}); err == nil {
+		t.Error("404: want an error")
+	}
+	if got.Method != "GET" {
+		t.Errorf("method = %s, want GET by default", got.Method)
+	}
+}
@@ -0,0 +1,203 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 500 * time.Millisecond
+	defaultMaxDelay    = 30 * time.Second
+)
+
+// defaultRetryStatuses are retried when the config doesn't list its own
+var defaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// requestStatsKey carries a site's request counters in a request context
+type requestStatsKey struct{}
+
+// requestStats counts the HTTP requests sent while scraping a single site
+// and the URLs that were skipped without a request
+type requestStats struct {
+	attempts    int64
+	retries     int64
+	interrupted int32 // Set by noteInterrupted
+
+	mu      sync.Mutex
+	skipped []models.SkippedURL
+}
+
+// withRequestStats attaches fresh request counters to ctx
+func withRequestStats(ctx context.Context) (context.Context, *requestStats) {
+	stats := &requestStats{}
+	return context.WithValue(ctx, requestStatsKey{}, stats), stats
+}
+
+// record copies the counters onto a scraping result
+func (s *requestStats) record(result *models.ScrapingResult) {
+	if result == nil {
+		return
+	}
+	result.Attempts = int(atomic.LoadInt64(&s.attempts))
+	result.Retries = int(atomic.LoadInt64(&s.retries))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result.Skipped = append(result.Skipped, s.skipped...)
+}
+
+// skip records a URL that was not requested and why
+func (s *requestStats) skip(url, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skipped = append(s.skipped, models.SkippedURL{URL: url, Reason: reason})
+}
+
+// retryTransport resends requests that failed with a network error or a retryable status,
+// backing off exponentially with jitter and honouring Retry-After
+type retryTransport struct {
+	base        http.RoundTripper
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	statuses    map[int]bool
+}
+
+// newRetryTransport wraps base with the retry policy of cfg
+func newRetryTransport(base http.RoundTripper, cfg config.ScraperConfig) *retryTransport {
+	t := &retryTransport{
+		base:        base,
+		maxAttempts: cfg.Retry.MaxAttempts,
+		baseDelay:   time.Duration(cfg.Retry.BaseDelay),
+		maxDelay:    time.Duration(cfg.Retry.MaxDelay),
+		statuses:    make(map[int]bool),
+	}
+	if t.maxAttempts <= 0 {
+		t.maxAttempts = defaultMaxAttempts
+	}
+	if t.baseDelay <= 0 {
+		t.baseDelay = defaultBaseDelay
+	}
+	if t.maxDelay <= 0 {
+		t.maxDelay = defaultMaxDelay
+	}
+
+	statuses := cfg.Retry.RetryStatuses
+	if len(statuses) == 0 {
+		statuses = defaultRetryStatuses
+	}
+	for _, status := range statuses {
+		t.statuses[status] = true
+	}
+	return t
+}
+
+// RoundTrip sends req, retrying it until it succeeds, fails permanently or runs out of attempts
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	stats, _ := ctx.Value(requestStatsKey{}).(*requestStats)
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			// Request bodies are consumed by the previous attempt
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req = req.Clone(ctx)
+				req.Body = body
+			}
+			if stats != nil {
+				atomic.AddInt64(&stats.retries, 1)
+			}
+		}
+		if stats != nil {
+			atomic.AddInt64(&stats.attempts, 1)
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil && ctx.Err() != nil {
+			noteInterrupted(ctx)
+		}
+
+		// A missing fixture stays missing, however often it is asked for
+		retryable := err != nil && ctx.Err() == nil && !errors.Is(err, errNoFixture)
+		if err == nil {
+			retryable = t.statuses[resp.StatusCode]
+		}
+		// A body that can't be replayed can't be sent twice
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			retryable = false
+		}
+		if !retryable || attempt >= t.maxAttempts {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				delay = retryAfter
+			}
+			// Drain the body so the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		if delay > t.maxDelay {
+			delay = t.maxDelay
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			noteInterrupted(ctx)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// backoff returns the delay before the given retry: the base delay doubled per attempt,
+// randomised over its upper half so requests that failed together don't retry together
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.baseDelay << (attempt - 1)
+	if delay <= 0 || delay > t.maxDelay {
+		delay = t.maxDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if delay := when.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,229 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"job-scraper/config"
+)
+
+// flakyServer answers with failures in order, then with the job board page
+type flakyServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	failures []int
+	requests int
+}
+
+func newFlakyServer(t *testing.T, failures []int, header http.Header) *flakyServer {
+	t.Helper()
+
+	board, err := os.ReadFile("testdata/html_board/page1.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &flakyServer{failures: failures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		var status int
+		if len(s.failures) > 0 {
+			status, s.failures = s.failures[0], s.failures[1:]
+		}
+		s.mu.Unlock()
+
+		if status != 0 {
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(errorPage))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(board)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// requestCount returns the requests received so far
+func (s *flakyServer) requestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// errorPage is the body of the failures, sizeable so an unread remainder would show
+var errorPage = strings.Repeat("Service unavailable. ", 1000)
+
+// bodyTracker records how much of each response body was read before it was closed
+type bodyTracker struct {
+	base http.RoundTripper
+	mu   sync.Mutex
+	read []int // Bytes read by the time each closed body was closed
+}
+
+func (b *bodyTracker) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := b.base.RoundTrip(req)
+	if err == nil {
+		resp.Body = &trackedBody{ReadCloser: resp.Body, tracker: b}
+	}
+	return resp, err
+}
+
+type trackedBody struct {
+	io.ReadCloser
+	tracker *bodyTracker
+	n       int
+}
+
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += n
+	return n, err
+}
+
+func (b *trackedBody) Close() error {
+	b.tracker.mu.Lock()
+	b.tracker.read = append(b.tracker.read, b.n)
+	b.tracker.mu.Unlock()
+	return b.ReadCloser.Close()
+}
+
+func TestScrapeSiteRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int // Retry statuses of the config, nil for the defaults
+		failures []int
+		attempts int
+		retries  int
+		jobs     int
+	}{
+		{"success", nil, nil, 1, 0, 1},
+		{"retryable statuses", nil, []int{503, 429}, 3, 2, 1},
+		{"out of attempts", nil, []int{500, 502, 504}, 3, 2, 0},
+		{"not retryable", nil, []int{404}, 1, 0, 0},
+		{"override retries other statuses", []int{404}, []int{404}, 2, 1, 1},
+		{"override replaces the defaults", []int{404}, []int{503}, 1, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFlakyServer(t, tt.failures, nil)
+			js := NewJobScraper(config.ScraperConfig{Retry: config.RetryConfig{
+				MaxAttempts:   3,
+				BaseDelay:     config.Duration(time.Millisecond),
+				RetryStatuses: tt.statuses,
+			}})
+
+			result, _ := js.ScrapeSite(context.Background(), config.Site{
+				Name:     "Board",
+				URL:      server.URL + "/jobs",
+				Selector: "li.job",
+			})
+
+			if result.Attempts != tt.attempts || result.Retries != tt.retries {
+				t.Errorf("attempts = %d, retries = %d; want %d and %d", result.Attempts, result.Retries, tt.attempts, tt.retries)
+			}
+			if requests := server.requestCount(); requests != tt.attempts {
+				t.Errorf("server saw %d requests, want %d", requests, tt.attempts)
+			}
+			if len(result.Jobs) != tt.jobs {
+				t.Errorf("got %d jobs, want %d", len(result.Jobs), tt.jobs)
+			}
+		})
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	send := func(t *testing.T, server *flakyServer, cfg config.RetryConfig) (*requestStats, *bodyTracker, time.Duration) {
+		t.Helper()
+
+		tracker := &bodyTracker{base: http.DefaultTransport}
+		client := &http.Client{Transport: newRetryTransport(tracker, config.ScraperConfig{Retry: cfg})}
+
+		ctx, stats := withRequestStats(context.Background())
+		req, err := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("status = %d, want 200", resp.StatusCode)
+		}
+		return stats, tracker, time.Since(start)
+	}
+
+	t.Run("body drained before retrying", func(t *testing.T) {
+		server := newFlakyServer(t, []int{503, 503}, nil)
+		stats, tracker, _ := send(t, server, config.RetryConfig{BaseDelay: config.Duration(time.Millisecond)})
+
+		if stats.attempts != 3 || stats.retries != 2 {
+			t.Errorf("attempts = %d, retries = %d; want 3 and 2", stats.attempts, stats.retries)
+		}
+		// Both failed responses were read to the end and closed, the last one by the caller
+		if len(tracker.read) != 3 || tracker.read[0] != len(errorPage) || tracker.read[1] != len(errorPage) {
+			t.Errorf("bytes read before close = %v, want the %d bytes of both error pages", tracker.read, len(errorPage))
+		}
+	})
+
+	t.Run("Retry-After", func(t *testing.T) {
+		server := newFlakyServer(t, []int{429}, http.Header{"Retry-After": {"1"}})
+		_, _, elapsed := send(t, server, config.RetryConfig{BaseDelay: config.Duration(time.Millisecond)})
+
+		if elapsed < time.Second {
+			t.Errorf("retried after %v, want the 1s of Retry-After", elapsed)
+		}
+	})
+
+	t.Run("Retry-After capped by max_delay", func(t *testing.T) {
+		server := newFlakyServer(t, []int{503}, http.Header{"Retry-After": {"3600"}})
+		_, _, elapsed := send(t, server, config.RetryConfig{
+			BaseDelay: config.Duration(time.Millisecond),
+			MaxDelay:  config.Duration(20 * time.Millisecond),
+		})
+
+		if elapsed > 500*time.Millisecond {
+			t.Errorf("retried after %v, want max_delay to cap Retry-After", elapsed)
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"Thu, 01 Oct 2026 12:00:30 GMT", 30 * time.Second, true},
+		{"Thu, 01 Oct 2026 11:00:00 GMT", 0, true},
+		{"-5", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/temoto/robotstxt"
+)
+
+// skipReasonRobots marks URLs skipped because robots.txt disallows them
+const skipReasonRobots = "robots_disallowed"
+
+// errRobotsDisallowed is returned for requests robots.txt doesn't allow
+var errRobotsDisallowed = errors.New("disallowed by robots.txt")
+
+// robotsKey marks a request context whose requests must honour robots.txt
+type robotsKey struct{}
+
+// withRobots makes requests sent with ctx honour robots.txt when the run is configured to
+func withRobots(ctx context.Context) context.Context {
+	return context.WithValue(ctx, robotsKey{}, true)
+}
+
+// robotsEntry caches the rules of a single host; once guards the fetch
+type robotsEntry struct {
+	once sync.Once
+	data *robotstxt.RobotsData
+}
+
+// robotsTransport refuses requests that the host's robots.txt disallows.
+// Only requests whose context was marked with withRobots are checked,
+// and rules are fetched once per host for the lifetime of the transport.
+type robotsTransport struct {
+	base    http.RoundTripper
+	enabled bool
+
+	mu    sync.Mutex
+	hosts map[string]*robotsEntry
+}
+
+// newRobotsTransport wraps base; with enabled false every request passes through
+func newRobotsTransport(base http.RoundTripper, enabled bool) *robotsTransport {
+	return &robotsTransport{
+		base:    base,
+		enabled: enabled,
+		hosts:   make(map[string]*robotsEntry),
+	}
+}
+
+// RoundTrip sends req unless robots.txt disallows it
+func (t *robotsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !t.enabled || ctx.Value(robotsKey{}) == nil || req.URL.Path == "/robots.txt" {
+		return t.base.RoundTrip(req)
+	}
+
+	robots := t.rules(ctx, req)
+	agent := req.Header.Get("User-Agent")
+	if agent == "" {
+		agent = "*"
+	}
+
+	if !robots.TestAgent(req.URL.RequestURI(), agent) {
+		if stats, ok := ctx.Value(requestStatsKey{}).(*requestStats); ok {
+			stats.skip(req.URL.String(), skipReasonRobots)
+		}
+		return nil, errRobotsDisallowed
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// rules returns the cached robots.txt rules of the request's host, fetching them on first use.
+// A robots.txt that can't be fetched or parsed allows everything.
+func (t *robotsTransport) rules(ctx context.Context, req *http.Request) *robotstxt.RobotsData {
+	key := req.URL.Scheme + "://" + req.URL.Host
+
+	t.mu.Lock()
+	entry, ok := t.hosts[key]
+	if !ok {
+		entry = &robotsEntry{}
+		t.hosts[key] = entry
+	}
+	t.mu.Unlock()
+
+	entry.once.Do(func() {
+		data, err := t.fetch(ctx, key, req.Header.Get("User-Agent"))
+		if err != nil {
+			fmt.Printf("Error fetching robots.txt for %s: %v\n", req.URL.Host, err)
+			data, _ = robotstxt.FromStatusAndBytes(http.StatusNotFound, nil)
+		}
+		entry.data = data
+	})
+	return entry.data
+}
+
+// fetch downloads and parses the robots.txt of a scheme://host root, following redirects
+func (t *robotsTransport) fetch(ctx context.Context, root, userAgent string) (*robotstxt.RobotsData, error) {
+	// The rules outlive the request that triggered the fetch, so its cancellation doesn't apply
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", root+"/robots.txt", nil)
+	if err != nil {
+		return nil, err
+	}
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	client := &http.Client{Transport: t.base, CheckRedirect: redirectPolicyFunc}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return robotstxt.FromResponse(resp)
+}
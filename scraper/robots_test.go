@@ -0,0 +1,150 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"job-scraper/config"
+)
+
+// robotsServer serves a robots.txt that disallows the Acme postings and /private,
+// the job board pages, and 404s for the detail pages. hits counts requests per path.
+type robotsServer struct {
+	*httptest.Server
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func newRobotsServer(t *testing.T) *robotsServer {
+	t.Helper()
+
+	pages := map[string]string{
+		"/jobs":         "html_board/page1.html",
+		"/jobs?page=2":  "html_board/page2.html",
+		"/private/jobs": "html_board/page1.html",
+	}
+
+	s := &robotsServer{hits: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.hits[r.URL.Path]++
+		s.mu.Unlock()
+
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte("User-agent: *\nDisallow: /remote-jobs/acme-\nDisallow: /private\n"))
+			return
+		}
+		file, ok := pages[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Errorf("reading payload: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(data)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// hitCount returns how often path was requested
+func (s *robotsServer) hitCount(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits[path]
+}
+
+func TestRobotsTxt(t *testing.T) {
+	server := newRobotsServer(t)
+	cfg := testConfig()
+	cfg.RespectRobotsTxt = true
+	js := NewJobScraper(cfg)
+
+	result, err := js.ScrapeSite(context.Background(), config.Site{
+		Name:        "Board",
+		URL:         server.URL + "/jobs",
+		Selector:    "li.job",
+		FollowLinks: true,
+		Pagination:  config.HTMLPagination{NextSelector: "a.next", MaxPages: 3},
+	})
+	if err != nil {
+		t.Fatalf("ScrapeSite: %v", err)
+	}
+
+	for _, path := range []string{"/remote-jobs/acme-senior-devops-engineer", "/remote-jobs/acme-office-manager"} {
+		if got := server.hitCount(path); got != 0 {
+			t.Errorf("disallowed %s requested %d times", path, got)
+		}
+	}
+	for _, path := range []string{"/remote-jobs/globex-site-reliability-engineer", "/remote-jobs/initech-platform-engineer"} {
+		if got := server.hitCount(path); got != 1 {
+			t.Errorf("allowed %s requested %d times, want 1", path, got)
+		}
+	}
+
+	var skipped []string
+	for _, s := range result.Skipped {
+		if s.Reason != skipReasonRobots {
+			t.Errorf("%s skipped as %q, want %q", s.URL, s.Reason, skipReasonRobots)
+		}
+		skipped = append(skipped, s.URL)
+	}
+	sort.Strings(skipped)
+	want := []string{server.URL + "/remote-jobs/acme-office-manager", server.URL + "/remote-jobs/acme-senior-devops-engineer"}
+	if len(skipped) != len(want) || skipped[0] != want[0] || skipped[1] != want[1] {
+		t.Errorf("skipped = %v, want %v", skipped, want)
+	}
+
+	// A disallowed start page is skipped rather than failing the site
+	result, err = js.ScrapeSite(context.Background(), config.Site{
+		Name:     "Private",
+		URL:      server.URL + "/private/jobs",
+		Selector: "li.job",
+	})
+	if err != nil {
+		t.Errorf("disallowed site: %v", err)
+	}
+	if server.hitCount("/private/jobs") != 0 || len(result.Jobs) != 0 {
+		t.Errorf("disallowed site was scraped: %d requests, %d jobs", server.hitCount("/private/jobs"), len(result.Jobs))
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].URL != server.URL+"/private/jobs" {
+		t.Errorf("skipped = %+v, want the start page", result.Skipped)
+	}
+
+	// Both sites share the rules fetched for the host
+	if got := server.hitCount("/robots.txt"); got != 1 {
+		t.Errorf("robots.txt fetched %d times, want once", got)
+	}
+}
+
+func TestRobotsTxtDisabled(t *testing.T) {
+	server := newRobotsServer(t)
+	js := NewJobScraper(testConfig())
+
+	result, err := js.ScrapeSite(context.Background(), config.Site{
+		Name:     "Private",
+		URL:      server.URL + "/private/jobs",
+		Selector: "li.job",
+	})
+	if err != nil {
+		t.Fatalf("ScrapeSite: %v", err)
+	}
+
+	if got := server.hitCount("/robots.txt"); got != 0 {
+		t.Errorf("robots.txt fetched %d times with respect_robots_txt off", got)
+	}
+	if server.hitCount("/private/jobs") != 1 || len(result.Skipped) != 0 {
+		t.Errorf("site requested %d times with %d skipped URLs, want 1 and none", server.hitCount("/private/jobs"), len(result.Skipped))
+	}
+}
@@ -2,7 +2,9 @@ package scraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"job-scraper/config"
 	"job-scraper/models"
 	"net"
@@ -10,7 +12,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly"
+	xhtml "golang.org/x/net/html"
 )
 
 // Scraper defines the interface for job scraping
@@ -21,6 +25,12 @@ type Scraper interface {
 	// ScrapeAPISite scrapes a single API site for job listings
 	ScrapeAPISite(ctx context.Context, apiSite config.APISite) (*models.ScrapingResult, error)
 
+	// ScrapeSource scrapes a single source of any kind
+	ScrapeSource(ctx context.Context, source config.Source) (*models.ScrapingResult, error)
+
+	// Run scrapes all sources and returns their combined, post-processed results
+	Run(ctx context.Context, sources []config.Source) (*models.RunResult, error)
+
 	// ScrapeAllSites scrapes all configured sites
 	ScrapeAllSites(ctx context.Context, sites []config.Site) ([]models.ScrapingResult, error)
 
@@ -30,7 +40,9 @@ type Scraper interface {
 
 // JobScraper implements the Scraper interface
 type JobScraper struct {
-	client *http.Client
+	client     *http.Client
+	apiScraper *APIScraper
+	cfg        config.ScraperConfig
 }
 
 // redirectPolicyFunc handles HTTP redirects
@@ -43,11 +55,12 @@ func redirectPolicyFunc(req *http.Request, via []*http.Request) error {
 }
 
 // NewJobScraper creates a new job scraper instance
-func NewJobScraper() *JobScraper {
-	// 1. Initialize HTTP client with proper timeouts
+func NewJobScraper(cfg config.ScraperConfig) *JobScraper {
+	// 1. Initialize HTTP client with proper timeouts; every request, colly's included,
+	// goes through the same transport so the concurrency limits hold across sources
 	client := &http.Client{
 		Timeout: 30 * time.Second, // Total request timeout
-		Transport: &http.Transport{
+		Transport: newTransport(&http.Transport{
 			DialContext: (&net.Dialer{
 				Timeout: 10 * time.Second, // Connection timeout
 			}).DialContext,
@@ -57,68 +70,221 @@ func NewJobScraper() *JobScraper {
 			MaxIdleConns:          100,              // Maximum idle connections
 			MaxIdleConnsPerHost:   10,               // Maximum idle connections per host
 			IdleConnTimeout:       90 * time.Second, // Idle connection timeout
-		},
+		}, cfg),
 		CheckRedirect: redirectPolicyFunc,
 	}
 
 	return &JobScraper{
-		client: client,
+		client:     client,
+		apiScraper: &APIScraper{client: client, cfg: cfg},
+		cfg:        cfg,
 	}
 }
 
 // ScrapeSite scrapes a single site for job listings
 func (js *JobScraper) ScrapeSite(ctx context.Context, site config.Site) (*models.ScrapingResult, error) {
+	ctx, cancel := withSiteTimeout(ctx, site.Timeout)
+	defer cancel()
+	ctx, stats := withRequestStats(withRobots(withRateLimit(ctx, site.RateLimit)))
+
+	var result *models.ScrapingResult
+	var err error
+	if site.Type == "sitemap" {
+		result, err = js.scrapeSitemap(ctx, site)
+	} else {
+		result, err = js.scrapeHTMLSite(ctx, site)
+	}
+
+	err = markInterrupted(ctx, result, err)
+	stats.record(result)
+	return result, err
+}
+
+// htmlPage collects what a single list page yielded until it is fully parsed
+type htmlPage struct {
+	hasLD      bool                // The page has JSON-LD postings, whether or not any passed the filters
+	ldJobs     []models.JobListing // JSON-LD postings that passed the filters
+	cardJobs   []models.JobListing // Cards that passed the filters
+	candidates []detailCandidate   // Cards waiting for their detail page
+}
+
+// scrapeHTMLSite scrapes the job cards of an HTML site
+func (js *JobScraper) scrapeHTMLSite(ctx context.Context, site config.Site) (*models.ScrapingResult, error) {
 	start := time.Now()
 	var jobs []models.JobListing
 
 	// Create a new collector
 	c := colly.NewCollector()
 
+	c.WithTransport(contextTransport{ctx: ctx, base: js.client.Transport})
+
+	// Stop queued pages once the scrape is cancelled or out of time
+	c.OnRequest(func(r *colly.Request) {
+		if ctx.Err() != nil {
+			noteInterrupted(ctx)
+			r.Abort()
+		}
+	})
+
 	// Set user agent to avoid being blocked
 	c.UserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"
 
-	// Set up the callback for job listings
-	c.OnHTML(site.Selector, func(e *colly.HTMLElement) {
-		// Extract basic job information
-		title := strings.TrimSpace(e.ChildText("h2, h3, .title, .job-title, a, .job-title-text"))
-		company := strings.TrimSpace(e.ChildText(".company, .company-name, .employer, .job-card-container__company-name"))
-		location := strings.TrimSpace(e.ChildText(".location, .job-location, .where, .job-card-container__metadata-item"))
-		url := e.Request.AbsoluteURL(e.ChildAttr("a", "href"))
-
-		// If URL is empty, try to get it from the element itself
-		if url == "" {
-			url = e.Request.AbsoluteURL(e.Attr("href"))
+	// Each page yields either its JSON-LD postings or its cards, decided once the page is parsed.
+	// When detail pages are followed, card filtering waits until the full description is known.
+	var candidates []detailCandidate
+	var page htmlPage
+	ldCount := 0
+	seen := make(map[string]bool)
+	newOnPage := 0
+	handleCard := func(card *goquery.Selection, req *colly.Request) {
+		job := cardJob(card, req, site)
+		if job.Title == "" {
+			return
+		}
+
+		// Result pages often overlap, so each card is only considered once
+		key := job.URL + "|" + job.Title + "|" + job.Company
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		newOnPage++
+
+		cardText := card.Text()
+		if site.FollowLinks {
+			page.candidates = append(page.candidates, detailCandidate{job: job, cardText: cardText})
+			return
 		}
 
-		// Check if this is a remote job
-		isRemote := js.isRemoteJob(title, company, location, e.Text)
-
-		// Check if this is a relevant role
-		isRelevantRole := js.isRelevantRole(title, e.Text)
-
-		// Create job listing if we have at least a title, it's remote, and it's a relevant role
-		if title != "" && isRemote && isRelevantRole {
-			job := models.JobListing{
-				ID:         fmt.Sprintf("%s-%d", site.Name, len(jobs)+1),
-				Title:      title,
-				Company:    company,
-				Location:   location,
-				URL:        url,
-				Source:     site.Name,
-				ScrapedAt:  time.Now(),
-				PostedDate: time.Now(), // We'll improve this later
+		// Create job listing if it's remote and it's a relevant role
+		fullText := job.Description + " " + cardText
+		if isRemoteJob(job.Title, job.Company, job.Location, fullText) && isRelevantRole(job.Title, fullText) {
+			page.cardJobs = append(page.cardJobs, job)
+		}
+	}
+
+	if expr, ok := strings.CutPrefix(site.Selector, xpathPrefix); ok {
+		c.OnXML(strings.TrimSpace(expr), func(e *colly.XMLElement) {
+			if node, ok := e.DOM.(*xhtml.Node); ok {
+				handleCard(goquery.NewDocumentFromNode(node).Selection, e.Request)
+			}
+		})
+	} else {
+		c.OnHTML(site.Selector, func(e *colly.HTMLElement) {
+			handleCard(e.DOM, e.Request)
+		})
+	}
+
+	// Structured schema.org JobPosting data is far more reliable than the CSS heuristics above
+	c.OnHTML(jobPostingLDSelector, func(e *colly.HTMLElement) {
+		for _, posting := range parseJobPostingsLD(e.Text) {
+			// Recorded before filtering: a page whose postings are all rejected must not fall back to its cards
+			page.hasLD = true
+
+			job := jobFromLD(posting, e.Request.URL.String())
+			job.URL = e.Request.AbsoluteURL(job.URL)
+
+			key := "ld|" + job.URL + "|" + job.Title + "|" + job.ID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			newOnPage++
+
+			if job.Title != "" && isRemoteJob(job.Title, job.Company, job.Location+" "+job.WorkplaceType, job.Description) &&
+				isRelevantRole(job.Title, job.Description) {
+				if job.ID == "" {
+					ldCount++
+					job.ID = fmt.Sprintf("%s-ld-%d", site.Name, ldCount)
+				} else {
+					job.ID = fmt.Sprintf("%s-%s", site.Name, job.ID)
+				}
+				job.Source = site.Name
+				page.ldJobs = append(page.ldJobs, job)
 			}
-			jobs = append(jobs, job)
+		}
+	})
+
+	// Pagination: the next page link is read while the page is parsed and visited once every
+	// card callback has run, so the number of new cards on the page is known
+	pagination := site.Pagination
+	pages := 0
+	var nextURL string
+	if pagination.NextSelector != "" {
+		c.OnHTML("html", func(e *colly.HTMLElement) {
+			if href := selectField(e.DOM, pagination.NextSelector, "href"); href != "" {
+				nextURL = e.Request.AbsoluteURL(href)
+			}
+		})
+	}
+
+	c.OnResponse(func(r *colly.Response) {
+		newOnPage = 0
+		nextURL = ""
+		page = htmlPage{}
+	})
+
+	c.OnScraped(func(r *colly.Response) {
+		// Prefer the page's JSON-LD postings over its cards when it has any
+		if page.hasLD {
+			jobs = append(jobs, page.ldJobs...)
+		} else {
+			for _, job := range page.cardJobs {
+				job.ID = fmt.Sprintf("%s-%d", site.Name, len(jobs)+1)
+				jobs = append(jobs, job)
+			}
+			candidates = append(candidates, page.candidates...)
+		}
+
+		// A next page left unvisited because ctx stopped is aborted, and noted, by OnRequest
+		pages++
+		if pages >= htmlMaxPages(pagination) {
+			return
+		}
+		if pagination.StopWhenNoNew && newOnPage == 0 {
+			return
+		}
+
+		next := nextURL
+		if pagination.URLTemplate != "" {
+			next = r.Request.AbsoluteURL(htmlPageURL(pagination, pages+1))
+		}
+		if next == "" {
+			return
+		}
+
+		// Visiting an already visited page fails, which ends loops between pages
+		if err := r.Request.Visit(next); err != nil && err != colly.ErrAlreadyVisited && !errors.Is(err, errRobotsDisallowed) {
+			fmt.Printf("Error visiting next page %s: %v\n", next, err)
 		}
 	})
 
 	// Set up error handling
 	c.OnError(func(r *colly.Response, err error) {
+		// Disallowed pages are reported as skipped and interruptions on the result, not as errors
+		if errors.Is(err, errRobotsDisallowed) {
+			return
+		}
+		if ctx.Err() != nil {
+			noteInterrupted(ctx)
+			return
+		}
 		fmt.Printf("Error scraping %s: %v\n", site.URL, err)
 	})
 
 	// Visit the site
 	err := c.Visit(site.URL)
+	if errors.Is(err, errRobotsDisallowed) {
+		err = nil
+	}
+
+	if len(candidates) > 0 {
+		for _, job := range js.fetchJobDetails(ctx, site, candidates) {
+			job.ID = fmt.Sprintf("%s-%d", site.Name, len(jobs)+1)
+			jobs = append(jobs, job)
+		}
+	}
+
 	if err != nil {
 		return &models.ScrapingResult{
 			Site:     site.Name,
@@ -135,237 +301,252 @@ func (js *JobScraper) ScrapeSite(ctx context.Context, site config.Site) (*models
 	}, nil
 }
 
-// isRemoteJob checks if a job is remote based on various indicators
-func (js *JobScraper) isRemoteJob(title, company, location, fullText string) bool {
-	// Convert to lowercase for case-insensitive matching
-	titleLower := strings.ToLower(title)
-	locationLower := strings.ToLower(location)
-	fullTextLower := strings.ToLower(fullText)
-
-	// Remote job indicators
-	remoteKeywords := []string{
-		"remote",
-		"work from home",
-		"wfh",
-		"virtual",
-		"distributed",
-		"telecommute",
-		"flexible location",
-		"anywhere",
-		"global",
-		"worldwide",
-	}
-
-	// Check if any remote keywords appear in title, location, or full text
-	for _, keyword := range remoteKeywords {
-		if strings.Contains(titleLower, keyword) ||
-			strings.Contains(locationLower, keyword) ||
-			strings.Contains(fullTextLower, keyword) {
-			return true
-		}
+// ScrapeAPISite scrapes a single API site for job listings
+func (js *JobScraper) ScrapeAPISite(ctx context.Context, apiSite config.APISite) (*models.ScrapingResult, error) {
+	// Delegate to the API scraper
+	return js.apiScraper.ScrapeAPISite(ctx, apiSite)
+}
+
+// ScrapeAllSites scrapes all configured sites through Run
+func (js *JobScraper) ScrapeAllSites(ctx context.Context, sites []config.Site) ([]models.ScrapingResult, error) {
+	sources := make([]config.Source, len(sites))
+	for i, site := range sites {
+		sources[i] = config.HTMLSource(site)
 	}
 
-	// Check for common non-remote indicators (if found, likely not remote)
-	nonRemoteKeywords := []string{
-		"on-site",
-		"onsite",
-		"in-person",
-		"office",
-		"headquarters",
-		"relocation required",
-		"must be local",
+	run, err := js.Run(ctx, sources)
+	return run.Results, err
+}
+
+// ScrapeAllAPISites scrapes all configured API sites through Run
+func (js *JobScraper) ScrapeAllAPISites(ctx context.Context, apiSites []config.APISite) ([]models.ScrapingResult, error) {
+	sources := make([]config.Source, len(apiSites))
+	for i, apiSite := range apiSites {
+		sources[i] = config.APISource(apiSite)
 	}
 
-	for _, keyword := range nonRemoteKeywords {
-		if strings.Contains(titleLower, keyword) ||
-			strings.Contains(locationLower, keyword) ||
-			strings.Contains(fullTextLower, keyword) {
-			return false
+	run, err := js.Run(ctx, sources)
+	return run.Results, err
+}
+
+// fillCancelled marks the results of sites that never ran because ctx was cancelled
+// and returns ctx's error, or nil when every site ran
+func fillCancelled(ctx context.Context, results []models.ScrapingResult, siteName func(i int) string) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	for i := range results {
+		if results[i].Site == "" {
+			results[i] = models.ScrapingResult{Site: siteName(i), Error: interruptedError(ctx)}
 		}
 	}
+	return interruptedError(ctx)
+}
 
-	// If location is empty or very generic, might be remote
-	if location == "" ||
-		strings.Contains(locationLower, "united states") ||
-		strings.Contains(locationLower, "usa") ||
-		strings.Contains(locationLower, "us") {
-		return true
+// cardJob extracts a job listing from a card using the site's selectors,
+// falling back to the built-in defaults for fields that aren't configured
+func cardJob(card *goquery.Selection, req *colly.Request, site config.Site) models.JobListing {
+	selectors := site.Selectors
+	field := func(name, configured, fallback string) string {
+		return selectField(card, fieldSelector(configured, fallback), fieldAttr(selectors, defaultCardSelectors, name))
 	}
 
-	return false
-}
+	job := models.JobListing{
+		Title:       field("title", selectors.Title, defaultCardSelectors.Title),
+		Company:     field("company", selectors.Company, defaultCardSelectors.Company),
+		Location:    field("location", selectors.Location, defaultCardSelectors.Location),
+		URL:         field("url", selectors.URL, defaultCardSelectors.URL),
+		Description: field("description", selectors.Description, ""),
+		Salary:      field("salary", selectors.Salary, ""),
+		Source:      site.Name,
+		ScrapedAt:   time.Now(),
+		PostedDate:  time.Now(),
+	}
 
-// isRelevantRole checks if a job title/description matches relevant engineering roles
-func (js *JobScraper) isRelevantRole(title, fullText string) bool {
-	// Convert to lowercase for case-insensitive matching
-	titleLower := strings.ToLower(title)
-	fullTextLower := strings.ToLower(fullText)
-
-	// Relevant role keywords
-	relevantKeywords := []string{
-		"system engineer",
-		"systems engineer",
-		"devops",
-		"dev ops",
-		"cloud engineer",
-		"sre",
-		"site reliability engineer",
-		"platform engineer",
-		"infrastructure engineer",
-		"reliability engineer",
-		"automation engineer",
-		"build engineer",
-		"release engineer",
-		"deployment engineer",
-		"kubernetes engineer",
-		"container engineer",
-		"aws engineer",
-		"azure engineer",
-		"gcp engineer",
-		"google cloud engineer",
-		"terraform engineer",
-		"ansible engineer",
-		"jenkins engineer",
-		"ci/cd engineer",
-		"monitoring engineer",
-		"observability engineer",
-		"security engineer",
-		"compliance engineer",
-		"network engineer",
-		"linux engineer",
-		"unix engineer",
-		"operations engineer",
-		"ops engineer",
-		"production engineer",
-		"backend engineer",
-		"api engineer",
-		"microservices engineer",
-		"distributed systems engineer",
-		"scalability engineer",
-		"performance engineer",
-		"data engineer",
-		"ml engineer",
-		"machine learning engineer",
-		"ai engineer",
-		"artificial intelligence engineer",
-	}
-
-	// Check if any relevant keywords appear in title or full text
-	for _, keyword := range relevantKeywords {
-		if strings.Contains(titleLower, keyword) ||
-			strings.Contains(fullTextLower, keyword) {
-			return true
-		}
+	// If URL is empty, try to get it from the element itself
+	if job.URL == "" {
+		job.URL, _ = card.Attr("href")
 	}
+	job.URL = req.AbsoluteURL(job.URL)
 
-	// Check for specific technology keywords that indicate relevant roles
-	techKeywords := []string{
-		"kubernetes",
-		"docker",
-		"terraform",
-		"ansible",
-		"puppet",
-		"chef",
-		"jenkins",
-		"gitlab ci",
-		"github actions",
-		"aws",
-		"azure",
-		"gcp",
-		"google cloud",
-		"amazon web services",
-		"microservices",
-		"containerization",
-		"orchestration",
-		"monitoring",
-		"observability",
-		"prometheus",
-		"grafana",
-		"elk stack",
-		"elasticsearch",
-		"splunk",
-		"datadog",
-		"new relic",
-		"pagerduty",
-		"incident response",
-		"disaster recovery",
-		"high availability",
-		"load balancing",
-		"auto scaling",
-		"infrastructure as code",
-		"configuration management",
-		"linux",
-		"unix",
-		"bash",
-		"shell scripting",
-		"python",
-		"golang",
-		"go",
-		"ruby",
-		"powershell",
-		"networking",
-		"security",
-		"compliance",
-		"automation",
-	}
-
-	// Count how many tech keywords appear
-	techCount := 0
-	for _, keyword := range techKeywords {
-		if strings.Contains(titleLower, keyword) ||
-			strings.Contains(fullTextLower, keyword) {
-			techCount++
-		}
+	if posted, ok := selectDate(card, selectors.PostedDate, fieldAttr(selectors, defaultCardSelectors, "posted_date")); ok {
+		job.PostedDate = posted
 	}
 
-	// If we find 2 or more tech keywords, it's likely a relevant role
-	return techCount >= 2
+	return job
 }
 
-// ScrapeAPISite scrapes a single API site for job listings
-func (js *JobScraper) ScrapeAPISite(ctx context.Context, apiSite config.APISite) (*models.ScrapingResult, error) {
-	// Delegate to the API scraper
-	apiScraper := NewAPIScraper()
-	return apiScraper.ScrapeAPISite(ctx, apiSite)
+// detailCandidate is a job card waiting for its detail page
+type detailCandidate struct {
+	job      models.JobListing
+	cardText string
+	fetched  bool // Set by fetchJobDetails once the detail page was parsed
 }
 
-// ScrapeAllSites scrapes all configured sites
-func (js *JobScraper) ScrapeAllSites(ctx context.Context, sites []config.Site) ([]models.ScrapingResult, error) {
-	var results []models.ScrapingResult
+// fetchJobDetails visits the detail page of every candidate with bounded concurrency,
+// merges the extracted fields and returns the remote, relevant jobs in card order
+func (js *JobScraper) fetchJobDetails(ctx context.Context, site config.Site, candidates []detailCandidate) []models.JobListing {
+	concurrency := site.DetailConcurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
 
-	for _, site := range sites {
-		result, err := js.ScrapeSite(ctx, site)
-		if err != nil {
-			fmt.Printf("Error scraping %s: %v\n", site.Name, err)
-		}
-		results = append(results, *result)
+	detailed := make([]models.JobListing, len(candidates))
+	for i, candidate := range candidates {
+		detailed[i] = candidate.job
 	}
 
-	return results, nil
-}
+	runPool(ctx, concurrency, len(candidates), func(ctx context.Context, i int) {
+		job := candidates[i].job
+		if job.URL == "" {
+			return
+		}
 
-// ScrapeAllAPISites scrapes all configured API sites
-func (js *JobScraper) ScrapeAllAPISites(ctx context.Context, apiSites []config.APISite) ([]models.ScrapingResult, error) {
-	var results []models.ScrapingResult
+		html, err := js.fetchPage(ctx, job.URL)
+		if err != nil {
+			if ctx.Err() != nil {
+				noteInterrupted(ctx)
+			} else if !errors.Is(err, errRobotsDisallowed) {
+				fmt.Printf("Error fetching job details %s: %v\n", job.URL, err)
+			}
+			return
+		}
 
-	for _, apiSite := range apiSites {
-		result, err := js.ScrapeAPISite(ctx, apiSite)
+		details, err := js.extractJobDetails(html, site)
 		if err != nil {
-			fmt.Printf("Error scraping API site %s: %v\n", apiSite.Name, err)
+			fmt.Printf("Error parsing job details %s: %v\n", job.URL, err)
+			return
+		}
+		detailed[i] = mergeJobDetails(job, *details)
+		candidates[i].fetched = true
+	})
+
+	var jobs []models.JobListing
+	for i, job := range detailed {
+		// Filter on the full description, falling back to the card text when the detail page failed
+		fullText := job.Description
+		if fullText == "" {
+			fullText = candidates[i].cardText
 		}
-		results = append(results, *result)
+
+		// Sitemap candidates only get their title from the detail page, so a failed or bare page leaves none
+		if job.Title != "" && isRemoteJob(job.Title, job.Company, job.Location+" "+job.WorkplaceType, fullText) &&
+			isRelevantRole(job.Title, fullText) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// fetchPage downloads an HTML page
+func (js *JobScraper) fetchPage(ctx context.Context, pageURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := js.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("page returned status %d", resp.StatusCode)
 	}
 
-	return results, nil
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
 }
 
-// extractJobDetails extracts job details from a job page
+// extractJobDetails extracts job details from a job page.
+// schema.org JobPosting data is used first; the site's detail selectors and
+// common description containers fill whatever is still missing.
 func (js *JobScraper) extractJobDetails(html string, site config.Site) (*models.JobListing, error) {
-	// TODO: Implement this function
-	// 1. Parse HTML using goquery
-	// 2. Extract job details based on site type
-	// 3. Handle different site formats (Indeed, LinkedIn, etc.)
-	// 4. Return JobListing struct
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %v", err)
+	}
+
+	job := &models.JobListing{}
+	doc.Find(jobPostingLDSelector).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		postings := parseJobPostingsLD(s.Text())
+		if len(postings) == 0 {
+			return true
+		}
+		*job = jobFromLD(postings[0], "")
+		// jobFromLD defaults the posted date to now; let the selectors try when it is missing
+		if _, ok := parseDateValue(postings[0]["datePosted"], ""); !ok {
+			job.PostedDate = time.Time{}
+		}
+		return false
+	})
+
+	selectors := site.DetailSelectors
+	page := doc.Selection
+	setIfEmpty := func(value *string, name, selector string) {
+		if *value != "" || selector == "" {
+			return
+		}
+		*value = selectField(page, selector, selectors.Attrs[name])
+	}
 
-	return &models.JobListing{}, nil
+	setIfEmpty(&job.Title, "title", selectors.Title)
+	setIfEmpty(&job.Company, "company", selectors.Company)
+	setIfEmpty(&job.Location, "location", selectors.Location)
+	setIfEmpty(&job.Salary, "salary", selectors.Salary)
+
+	if job.Description == "" {
+		descriptionSelector := fieldSelector(selectors.Description, "#job-description, .job-description, .description, [itemprop=description], .show-more-less-html__markup")
+		if attr := selectors.Attrs["description"]; attr != "" || strings.HasPrefix(descriptionSelector, xpathPrefix) {
+			job.Description = selectField(page, descriptionSelector, attr)
+		} else if content, err := doc.Find(descriptionSelector).First().Html(); err == nil {
+			job.Description = htmlToText(content)
+		}
+	}
+
+	if job.PostedDate.IsZero() {
+		job.PostedDate, _ = selectDate(page, selectors.PostedDate, selectors.Attrs["posted_date"])
+	}
+
+	return job, nil
+}
+
+// mergeJobDetails fills the card fields with what the detail page provides
+func mergeJobDetails(card, details models.JobListing) models.JobListing {
+	merged := card
+	if merged.Title == "" {
+		merged.Title = details.Title
+	}
+	if details.Company != "" {
+		merged.Company = details.Company
+	}
+	if details.Location != "" {
+		merged.Location = details.Location
+	}
+	if details.Description != "" {
+		merged.Description = details.Description
+	}
+	if details.Salary != "" {
+		merged.Salary = details.Salary
+	}
+	if details.EmploymentType != "" {
+		merged.EmploymentType = details.EmploymentType
+	}
+	if details.WorkplaceType != "" {
+		merged.WorkplaceType = details.WorkplaceType
+	}
+	if !details.PostedDate.IsZero() {
+		merged.PostedDate = details.PostedDate
+	}
+	if !details.ValidThrough.IsZero() {
+		merged.ValidThrough = details.ValidThrough
+	}
+	return merged
 }
@@ -0,0 +1,194 @@
+package scraper
+
+import (
+	"context"
+	"flag"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"job-scraper/config"
+)
+
+// record re-records the fixtures of the replayed tests: go test ./scraper -record
+var record = flag.Bool("record", false, "re-record the fixtures under testdata/fixtures from the payloads under testdata")
+
+// fixtureHost is the host the replayed tests request. Fixtures are keyed on the URL,
+// so it stays the same whatever port the recording server listened on.
+const fixtureHost = "http://jobs.fixtures.test"
+
+// testConfig is the scraper config of the tests. Retries are off so failures show at once.
+func testConfig() config.ScraperConfig {
+	return config.ScraperConfig{Retry: config.RetryConfig{MaxAttempts: 1}}
+}
+
+// newTestServer serves the hand-written payloads under testdata. routes maps a request's
+// path and query (e.g. "/boards/acme/jobs?content=true") to a file; anything else is a 404.
+func newTestServer(t *testing.T, routes map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := routes[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		data, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Errorf("reading payload: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		switch filepath.Ext(file) {
+		case ".json":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		case ".html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		case ".xml":
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// dialServer returns a transport that sends requests for every host to server
+func dialServer(server *httptest.Server) *http.Transport {
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+		},
+	}
+}
+
+// newReplayScraper returns a scraper that replays the fixtures under testdata/fixtures/<name>
+// and never touches the network. With -record it first records them again: the requests,
+// sent to fixtureHost, are answered by newTestServer from routes and saved as fixtures.
+func newReplayScraper(t *testing.T, name string, routes map[string]string) *JobScraper {
+	t.Helper()
+
+	cfg := testConfig()
+	cfg.Fixtures = config.FixturesConfig{Dir: filepath.Join("testdata", "fixtures", name), Mode: "replay"}
+	if !*record {
+		return NewJobScraper(cfg)
+	}
+
+	if err := os.RemoveAll(cfg.Fixtures.Dir); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Fixtures.Mode = "record"
+	js := NewJobScraper(cfg)
+	js.client.Transport = newTransport(dialServer(newTestServer(t, routes)), cfg)
+	return js
+}
+
+func TestScrapeSite(t *testing.T) {
+	js := newReplayScraper(t, "html_board", map[string]string{
+		"/jobs":        "html_board/page1.html",
+		"/jobs?page=2": "html_board/page2.html",
+	})
+
+	result, err := js.ScrapeSite(context.Background(), config.Site{
+		Name:       "Board",
+		URL:        fixtureHost + "/jobs",
+		Selector:   "li.job",
+		Pagination: config.HTMLPagination{NextSelector: "a.next", MaxPages: 3},
+	})
+	if err != nil {
+		t.Fatalf("ScrapeSite: %v", err)
+	}
+
+	want := []struct{ id, title, company, location, url string }{
+		{"Board-1", "Senior DevOps Engineer", "Acme", "Remote - Worldwide", fixtureHost + "/remote-jobs/acme-senior-devops-engineer"},
+		{"Board-2", "Platform Engineer (Kubernetes)", "Initech", "Anywhere", fixtureHost + "/remote-jobs/initech-platform-engineer"},
+	}
+	if len(result.Jobs) != len(want) {
+		t.Fatalf("got %d jobs, want %d: %+v", len(result.Jobs), len(want), result.Jobs)
+	}
+	for i, w := range want {
+		job := result.Jobs[i]
+		if job.ID != w.id || job.Title != w.title || job.Company != w.company || job.Location != w.location || job.URL != w.url {
+			t.Errorf("job %d = {%q %q %q %q %q}, want %+v", i, job.ID, job.Title, job.Company, job.Location, job.URL, w)
+		}
+		if job.Source != "Board" {
+			t.Errorf("job %d source = %q", i, job.Source)
+		}
+	}
+
+	// Two pages, each one request
+	if result.Attempts != 2 || result.Retries != 0 {
+		t.Errorf("attempts = %d, retries = %d; want 2 and 0", result.Attempts, result.Retries)
+	}
+}
+
+func TestScrapeSiteNotFound(t *testing.T) {
+	js := newReplayScraper(t, "not_found", nil)
+
+	result, err := js.ScrapeSite(context.Background(), config.Site{
+		Name:     "Gone",
+		URL:      fixtureHost + "/jobs",
+		Selector: "li.job",
+	})
+	if err == nil || !strings.Contains(err.Error(), "Not Found") {
+		t.Fatalf("err = %v, want a not found error", err)
+	}
+	if len(result.Jobs) != 0 {
+		t.Errorf("got %d jobs, want none", len(result.Jobs))
+	}
+}
+
+func TestScrapeSiteFollowLinks(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/jobs": "html_board/page1.html",
+		"/remote-jobs/acme-senior-devops-engineer":      "html_board/devops-detail.html",
+		"/remote-jobs/globex-site-reliability-engineer": "html_board/sre-detail.html",
+	})
+	js := NewJobScraper(testConfig())
+
+	result, err := js.ScrapeSite(context.Background(), config.Site{
+		Name:        "Board",
+		URL:         server.URL + "/jobs",
+		Selector:    "li.job",
+		FollowLinks: true,
+		DetailSelectors: config.FieldSelectors{
+			Location:   ".job-location",
+			Salary:     ".salary",
+			PostedDate: "time",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ScrapeSite: %v", err)
+	}
+
+	// The office manager's detail page is missing, so its card text decides and it stays out.
+	// The on-site card turns out to be remote on its detail page.
+	if len(result.Jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2: %+v", len(result.Jobs), result.Jobs)
+	}
+
+	devops := result.Jobs[0]
+	if devops.Title != "Senior DevOps Engineer" || devops.Company != "Acme" || devops.Location != "Remote - Worldwide" {
+		t.Errorf("card fields not kept: %+v", devops)
+	}
+	if devops.Description != "Run our Terraform and Kubernetes platform from anywhere." || devops.Salary != "$140k - $170k" {
+		t.Errorf("description %q, salary %q; want the detail page's", devops.Description, devops.Salary)
+	}
+	if want := time.Date(2026, 9, 20, 0, 0, 0, 0, time.UTC); !devops.PostedDate.Equal(want) {
+		t.Errorf("posted date = %v, want the <time> datetime %v", devops.PostedDate, want)
+	}
+
+	if sre := result.Jobs[1]; sre.Title != "Site Reliability Engineer" || sre.Location != "Remote (EU time zones)" {
+		t.Errorf("second job = %+v, want the detail page's location", sre)
+	}
+	if result.Jobs[0].ID != "Board-1" || result.Jobs[1].ID != "Board-2" {
+		t.Errorf("IDs = %q, %q; want them numbered in card order", result.Jobs[0].ID, result.Jobs[1].ID)
+	}
+}
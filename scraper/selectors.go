@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/antchfx/htmlquery"
+
+	"job-scraper/config"
+)
+
+// xpathPrefix marks a selector as an XPath expression rather than CSS
+const xpathPrefix = "xpath:"
+
+// defaultCardSelectors are used for the card fields a site doesn't configure
+var defaultCardSelectors = config.FieldSelectors{
+	Title:    "h2, h3, .title, .job-title, a, .job-title-text",
+	Company:  ".company, .company-name, .employer, .job-card-container__company-name",
+	Location: ".location, .job-location, .where, .job-card-container__metadata-item",
+	URL:      "a",
+	Attrs:    map[string]string{"url": "href"},
+}
+
+// fieldSelector returns the configured selector for a field, or the fallback when none is set
+func fieldSelector(configured, fallback string) string {
+	if configured != "" {
+		return configured
+	}
+	return fallback
+}
+
+// fieldAttr returns the attribute a field is read from, or "" to read the element text.
+// A configured attribute wins over the default one.
+func fieldAttr(selectors, defaults config.FieldSelectors, field string) string {
+	if attr, ok := selectors.Attrs[field]; ok {
+		return attr
+	}
+	return defaults.Attrs[field]
+}
+
+// selectField returns the text, or the attr attribute, of the first element matched by selector within s
+func selectField(s *goquery.Selection, selector, attr string) string {
+	if selector == "" {
+		return ""
+	}
+
+	if expr, ok := strings.CutPrefix(selector, xpathPrefix); ok {
+		for _, node := range s.Nodes {
+			found, err := htmlquery.Query(node, strings.TrimSpace(expr))
+			if err != nil || found == nil {
+				continue
+			}
+			if attr != "" {
+				return strings.TrimSpace(htmlquery.SelectAttr(found, attr))
+			}
+			return cleanText(htmlquery.InnerText(found))
+		}
+		return ""
+	}
+
+	match := s.Find(selector).First()
+	if attr != "" {
+		value, _ := match.Attr(attr)
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(match.Text())
+}
+
+// selectDate parses the date matched by selector. Without an explicit attribute,
+// a <time datetime="..."> value is preferred over the element text.
+func selectDate(s *goquery.Selection, selector, attr string) (time.Time, bool) {
+	if selector == "" {
+		return time.Time{}, false
+	}
+
+	if attr == "" && !strings.HasPrefix(selector, xpathPrefix) {
+		if datetime, ok := s.Find(selector).First().Attr("datetime"); ok {
+			if parsed, ok := parseDateValue(datetime, ""); ok {
+				return parsed, true
+			}
+		}
+	}
+
+	text := selectField(s, selector, attr)
+	if parsed, ok := parseDateValue(text, ""); ok {
+		return parsed, true
+	}
+	return parseRelativeDate(text, time.Now())
+}
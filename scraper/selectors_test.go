@@ -0,0 +1,121 @@
+package scraper
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"job-scraper/config"
+)
+
+func TestSelectField(t *testing.T) {
+	data, err := os.ReadFile("testdata/selectors_board/index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	card := doc.Find("div.posting").First()
+
+	tests := []struct {
+		name, selector, attr, want string
+	}{
+		{"css text", "span.role", "", "Senior DevOps Engineer"},
+		{"css attribute", "span.org", "data-name", "Acme"},
+		{"xpath text", "xpath:.//span[@class='where']", "", "Remote - Europe"},
+		{"xpath attribute", "xpath:.//time", "datetime", "2026-09-15"},
+		{"xpath relative to the card", "xpath:.//span[@class='role']", "", "Senior DevOps Engineer"},
+		{"no match", "span.salary", "", ""},
+		{"invalid xpath", "xpath:.//span[", "", ""},
+		{"empty selector", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectField(card, tt.selector, tt.attr); got != tt.want {
+				t.Errorf("selectField(%q, %q) = %q, want %q", tt.selector, tt.attr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScrapeSiteSelectors(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/careers": "selectors_board/index.html",
+	})
+
+	sites := []struct {
+		name      string
+		selector  string
+		selectors config.FieldSelectors
+	}{
+		{
+			name:     "css",
+			selector: "div.posting",
+			selectors: config.FieldSelectors{
+				Title:      "span.role",
+				Company:    "span.org",
+				Location:   "span.where",
+				PostedDate: "time",
+				Attrs:      map[string]string{"company": "data-name"},
+			},
+		},
+		{
+			name:     "xpath",
+			selector: "xpath://section[@id='openings']/div[@class='posting']",
+			selectors: config.FieldSelectors{
+				Title:      "xpath:./span[@class='role']",
+				Company:    "xpath:./span[@class='org']",
+				Location:   "xpath:./span[@class='where']",
+				PostedDate: "xpath:./time",
+				Attrs:      map[string]string{"company": "data-name", "posted_date": "datetime"},
+			},
+		},
+	}
+
+	for _, tt := range sites {
+		t.Run(tt.name, func(t *testing.T) {
+			// The URL lives on the card itself, so it is read from the card's attribute
+			selectors := tt.selectors
+			selectors.URL = "xpath:."
+			selectors.Attrs["url"] = "data-href"
+
+			js := NewJobScraper(testConfig())
+			result, err := js.ScrapeSite(context.Background(), config.Site{
+				Name:      "Careers",
+				URL:       server.URL + "/careers",
+				Selector:  tt.selector,
+				Selectors: selectors,
+			})
+			if err != nil {
+				t.Fatalf("ScrapeSite: %v", err)
+			}
+
+			want := []struct {
+				title, company, location, url string
+				posted                        time.Time
+			}{
+				{"Senior DevOps Engineer", "Acme", "Remote - Europe", server.URL + "/careers/101", time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)},
+				{"Platform Engineer", "Initech", "Anywhere", server.URL + "/careers/102", time.Date(2026, 9, 20, 0, 0, 0, 0, time.UTC)},
+			}
+			if len(result.Jobs) != len(want) {
+				t.Fatalf("got %d jobs, want %d: %+v", len(result.Jobs), len(want), result.Jobs)
+			}
+			for i, w := range want {
+				job := result.Jobs[i]
+				if job.Title != w.title || job.Company != w.company || job.Location != w.location || job.URL != w.url {
+					t.Errorf("job %d = {%q %q %q %q}, want %+v", i, job.Title, job.Company, job.Location, job.URL, w)
+				}
+				if !job.PostedDate.Equal(w.posted) {
+					t.Errorf("job %d posted = %v, want %v", i, job.PostedDate, w.posted)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package scraper
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// sitemapMaxDepth limits how deep sitemap indexes may nest
+const sitemapMaxDepth = 3
+
+// sitemapEntry is a single <url> of a sitemap urlset
+type sitemapEntry struct {
+	Loc     string
+	LastMod string
+}
+
+// scrapeSitemap discovers postings through a site's sitemap and extracts each one from its detail page.
+// With a state file, postings whose lastmod hasn't changed since the previous run are skipped.
+func (js *JobScraper) scrapeSitemap(ctx context.Context, site config.Site) (*models.ScrapingResult, error) {
+	start := time.Now()
+	result := &models.ScrapingResult{Site: site.Name}
+
+	fail := func(err error) (*models.ScrapingResult, error) {
+		result.Error = err
+		result.Duration = time.Since(start)
+		return result, err
+	}
+
+	var pattern *regexp.Regexp
+	if site.URLPattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(site.URLPattern); err != nil {
+			return fail(fmt.Errorf("invalid url_pattern for %s: %v", site.Name, err))
+		}
+	}
+
+	state, err := loadSitemapState(site.StateFile)
+	if err != nil {
+		return fail(err)
+	}
+
+	entries, err := js.fetchSitemapEntries(ctx, site.URL, 0, make(map[string]bool))
+	if errors.Is(err, errRobotsDisallowed) {
+		result.Duration = time.Since(start)
+		return result, nil
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	var candidates []detailCandidate
+	for _, entry := range entries {
+		if pattern != nil && !pattern.MatchString(entry.Loc) {
+			continue
+		}
+		if entry.LastMod != "" && state[entry.Loc] == entry.LastMod {
+			continue
+		}
+
+		job := models.JobListing{
+			URL:        entry.Loc,
+			Source:     site.Name,
+			ScrapedAt:  time.Now(),
+			PostedDate: time.Now(),
+		}
+		// lastmod is the best posting date available until the detail page says otherwise
+		if modified, ok := parseDateValue(entry.LastMod, ""); ok {
+			job.PostedDate = modified
+		}
+		candidates = append(candidates, detailCandidate{job: job})
+	}
+
+	for _, job := range js.fetchJobDetails(ctx, site, candidates) {
+		job.ID = fmt.Sprintf("%s-%d", site.Name, len(result.Jobs)+1)
+		result.Jobs = append(result.Jobs, job)
+	}
+
+	// Only remember postings that were actually read, so failures are retried next run
+	if site.StateFile != "" {
+		lastMods := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			lastMods[entry.Loc] = entry.LastMod
+		}
+		for _, candidate := range candidates {
+			if candidate.fetched && lastMods[candidate.job.URL] != "" {
+				state[candidate.job.URL] = lastMods[candidate.job.URL]
+			}
+		}
+		if err := saveSitemapState(site.StateFile, state); err != nil {
+			fmt.Printf("Error saving sitemap state %s: %v\n", site.StateFile, err)
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// fetchSitemapEntries returns the postings listed by a urlset, following sitemap indexes.
+// visited guards against indexes that reference each other.
+func (js *JobScraper) fetchSitemapEntries(ctx context.Context, sitemapURL string, depth int, visited map[string]bool) ([]sitemapEntry, error) {
+	if visited[sitemapURL] {
+		return nil, nil
+	}
+	visited[sitemapURL] = true
+
+	page, err := js.fetchPage(ctx, sitemapURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sitemap %s: %w", sitemapURL, err)
+	}
+
+	body, err := decompressSitemap([]byte(page))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress sitemap %s: %v", sitemapURL, err)
+	}
+
+	doc, err := xmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sitemap %s: %v", sitemapURL, err)
+	}
+
+	// A sitemap index only points at further sitemaps
+	if children := xmlquery.Find(doc, "//*[local-name()='sitemapindex']/*[local-name()='sitemap']"); len(children) > 0 {
+		if depth >= sitemapMaxDepth {
+			return nil, fmt.Errorf("sitemap index %s nested too deeply", sitemapURL)
+		}
+
+		var entries []sitemapEntry
+		for _, child := range children {
+			loc := childText(child, "loc")
+			if loc == "" {
+				continue
+			}
+			childEntries, err := js.fetchSitemapEntries(ctx, loc, depth+1, visited)
+			if err != nil {
+				if !errors.Is(err, errRobotsDisallowed) {
+					fmt.Printf("Error reading sitemap %s: %v\n", loc, err)
+				}
+				continue
+			}
+			entries = append(entries, childEntries...)
+		}
+		return entries, nil
+	}
+
+	var entries []sitemapEntry
+	for _, node := range xmlquery.Find(doc, "//*[local-name()='urlset']/*[local-name()='url']") {
+		if loc := childText(node, "loc"); loc != "" {
+			entries = append(entries, sitemapEntry{Loc: loc, LastMod: childText(node, "lastmod")})
+		}
+	}
+	return entries, nil
+}
+
+// decompressSitemap gunzips sitemap.xml.gz files, which servers deliver without Content-Encoding
+func decompressSitemap(body []byte) ([]byte, error) {
+	if len(body) < 2 || body[0] != 0x1f || body[1] != 0x8b {
+		return body, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// loadSitemapState reads the lastmod value recorded for each posting URL.
+// A missing file yields an empty state.
+func loadSitemapState(path string) (map[string]string, error) {
+	state := make(map[string]string)
+	if path == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sitemap state %s: %v", path, err)
+	}
+
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return state, nil
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse sitemap state %s: %v", path, err)
+	}
+	return state, nil
+}
+
+// saveSitemapState writes the lastmod value of each posting URL
+func saveSitemapState(path string, state map[string]string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
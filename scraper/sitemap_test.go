@@ -0,0 +1,168 @@
+package scraper
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"job-scraper/config"
+)
+
+// sitemapServer serves a sitemap index that points at a gzipped urlset of postings and a plain
+// urlset of other pages. lastMods holds the lastmod of each posting path and may be changed
+// between runs; hits counts the requests per path.
+type sitemapServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	lastMods map[string]string
+	hits     map[string]int
+}
+
+func newSitemapServer(t *testing.T) *sitemapServer {
+	t.Helper()
+
+	s := &sitemapServer{
+		lastMods: map[string]string{
+			"/jobs/devops":   "2026-09-01",
+			"/jobs/platform": "2026-09-02",
+			"/jobs/untitled": "2026-09-03",
+		},
+		hits: make(map[string]int),
+	}
+	details := map[string]string{
+		"/jobs/devops":   "sitemap/devops.html",
+		"/jobs/platform": "sitemap/platform.html",
+		"/jobs/untitled": "sitemap/untitled.html",
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.hits[r.URL.Path]++
+		s.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/sitemap_index.xml":
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>%[1]s/sitemap-jobs.xml.gz</loc></sitemap>
+  <sitemap><loc>%[1]s/sitemap-pages.xml</loc></sitemap>
+</sitemapindex>`, s.URL)
+		case "/sitemap-jobs.xml.gz":
+			var urls bytes.Buffer
+			s.mu.Lock()
+			for _, path := range []string{"/jobs/devops", "/jobs/platform", "/jobs/untitled"} {
+				fmt.Fprintf(&urls, "  <url><loc>%s%s</loc><lastmod>%s</lastmod></url>\n", s.URL, path, s.lastMods[path])
+			}
+			s.mu.Unlock()
+
+			// Served as a plain download, without Content-Encoding, like most .xml.gz sitemaps
+			w.Header().Set("Content-Type", "application/x-gzip")
+			gz := gzip.NewWriter(w)
+			fmt.Fprintf(gz, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+%s</urlset>`, urls.String())
+			gz.Close()
+		case "/sitemap-pages.xml":
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>%[1]s/about</loc></url>
+  <url><loc>%[1]s/jobs</loc></url>
+</urlset>`, s.URL)
+		default:
+			file, ok := details[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			data, err := os.ReadFile(filepath.Join("testdata", file))
+			if err != nil {
+				t.Errorf("reading payload: %v", err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write(data)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// hitCount returns how often path was requested
+func (s *sitemapServer) hitCount(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits[path]
+}
+
+func TestScrapeSitemap(t *testing.T) {
+	server := newSitemapServer(t)
+	site := config.Site{
+		Name:       "Acme",
+		Type:       "sitemap",
+		URL:        server.URL + "/sitemap_index.xml",
+		URLPattern: `/jobs/[a-z]+$`,
+		StateFile:  filepath.Join(t.TempDir(), "sitemap-state.json"),
+		DetailSelectors: config.FieldSelectors{
+			Title:    "h1",
+			Company:  ".company",
+			Location: ".location",
+		},
+	}
+	js := NewJobScraper(testConfig())
+
+	scrape := func() []string {
+		t.Helper()
+		result, err := js.ScrapeSite(context.Background(), site)
+		if err != nil {
+			t.Fatalf("ScrapeSite: %v", err)
+		}
+		var titles []string
+		for _, job := range result.Jobs {
+			titles = append(titles, job.Title)
+		}
+		return titles
+	}
+
+	// The untitled posting is read but dropped; pages outside url_pattern are never requested
+	titles := scrape()
+	if fmt.Sprint(titles) != "[Senior DevOps Engineer Platform Engineer]" {
+		t.Errorf("first run titles = %q", titles)
+	}
+	for _, path := range []string{"/sitemap-jobs.xml.gz", "/sitemap-pages.xml", "/jobs/devops", "/jobs/platform", "/jobs/untitled"} {
+		if got := server.hitCount(path); got != 1 {
+			t.Errorf("%s requested %d times, want 1", path, got)
+		}
+	}
+	for _, path := range []string{"/about", "/jobs"} {
+		if got := server.hitCount(path); got != 0 {
+			t.Errorf("%s requested %d times, want 0", path, got)
+		}
+	}
+
+	// Nothing changed, so no posting is fetched again
+	if titles := scrape(); len(titles) != 0 {
+		t.Errorf("second run titles = %q, want none", titles)
+	}
+	if got := server.hitCount("/jobs/devops"); got != 1 {
+		t.Errorf("/jobs/devops requested %d times after an unchanged run, want 1", got)
+	}
+
+	// Only the posting whose lastmod moved is fetched again
+	server.mu.Lock()
+	server.lastMods["/jobs/platform"] = "2026-10-01"
+	server.mu.Unlock()
+	if titles := scrape(); fmt.Sprint(titles) != "[Platform Engineer]" {
+		t.Errorf("third run titles = %q", titles)
+	}
+	if devops, platform := server.hitCount("/jobs/devops"), server.hitCount("/jobs/platform"); devops != 1 || platform != 2 {
+		t.Errorf("detail requests = %d devops, %d platform; want 1 and 2", devops, platform)
+	}
+}
@@ -0,0 +1,137 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// defaultSmartRecruitersBaseURL is SmartRecruiters' public posting API
+const defaultSmartRecruitersBaseURL = "https://api.smartrecruiters.com/v1/companies"
+
+// smartRecruitersPageSize is the largest page the posting API accepts
+const smartRecruitersPageSize = 100
+
+// smartRecruitersPosting mirrors a single posting of the SmartRecruiters posting API
+type smartRecruitersPosting struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Company struct {
+		Identifier string `json:"identifier"`
+		Name       string `json:"name"`
+	} `json:"company"`
+	ReleasedDate string `json:"releasedDate"`
+	Location     struct {
+		City         string `json:"city"`
+		Region       string `json:"region"`
+		Country      string `json:"country"`
+		Remote       bool   `json:"remote"`
+		Hybrid       bool   `json:"hybrid"`
+		FullLocation string `json:"fullLocation"`
+	} `json:"location"`
+	Department struct {
+		Label string `json:"label"`
+	} `json:"department"`
+	Function struct {
+		Label string `json:"label"`
+	} `json:"function"`
+	TypeOfEmployment struct {
+		Label string `json:"label"`
+	} `json:"typeOfEmployment"`
+}
+
+func init() {
+	registerSource(sourceAdapter{
+		Type:     "smartrecruiters_api",
+		Required: []string{"companies"},
+		Fetch:    (*APIScraper).scrapeSmartRecruitersAPI,
+	})
+}
+
+// scrapeSmartRecruitersAPI scrapes multiple SmartRecruiters companies
+func (as *APIScraper) scrapeSmartRecruitersAPI(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
+	baseURL := apiSite.BaseURL
+	if baseURL == "" {
+		baseURL = defaultSmartRecruitersBaseURL
+	}
+
+	return as.scrapeCompanies(ctx, apiSite.Companies, func(ctx context.Context, company string) ([]models.JobListing, error) {
+		return as.scrapeSingleSmartRecruitersCompany(ctx, baseURL, company)
+	}), nil
+}
+
+// scrapeSingleSmartRecruitersCompany scrapes a single company, paging with offset/limit until totalFound is reached
+func (as *APIScraper) scrapeSingleSmartRecruitersCompany(ctx context.Context, baseURL, company string) ([]models.JobListing, error) {
+	var jobs []models.JobListing
+
+	for offset := 0; ; offset += smartRecruitersPageSize {
+		apiURL := fmt.Sprintf("%s/%s/postings?offset=%d&limit=%d",
+			strings.TrimRight(baseURL, "/"), url.PathEscape(company), offset, smartRecruitersPageSize)
+
+		var response struct {
+			Offset     int                      `json:"offset"`
+			Limit      int                      `json:"limit"`
+			TotalFound int                      `json:"totalFound"`
+			Content    []smartRecruitersPosting `json:"content"`
+		}
+		if err := as.getJSON(ctx, apiURL, &response); err != nil {
+			return jobs, err
+		}
+
+		for _, posting := range response.Content {
+			location := posting.Location.FullLocation
+			if location == "" {
+				location = joinNonEmpty(", ", posting.Location.City, posting.Location.Region, strings.ToUpper(posting.Location.Country))
+			}
+
+			workplaceType := ""
+			switch {
+			case posting.Location.Remote:
+				workplaceType = "remote"
+			case posting.Location.Hybrid:
+				workplaceType = "hybrid"
+			}
+
+			if isRemoteJob(posting.Name, company, location, workplaceType) && isRelevantRole(posting.Name, "") {
+				companyName := posting.Company.Name
+				if companyName == "" {
+					companyName = strings.Title(company) // Capitalize company name
+				}
+
+				department := posting.Department.Label
+				if department == "" {
+					department = posting.Function.Label
+				}
+
+				jobListing := models.JobListing{
+					ID:             fmt.Sprintf("smartrecruiters-%s-%s", company, posting.ID),
+					Title:          posting.Name,
+					Company:        companyName,
+					Location:       location,
+					URL:            fmt.Sprintf("https://jobs.smartrecruiters.com/%s/%s", url.PathEscape(company), posting.ID),
+					Source:         fmt.Sprintf("SmartRecruiters - %s", companyName),
+					Department:     department,
+					EmploymentType: posting.TypeOfEmployment.Label,
+					WorkplaceType:  workplaceType,
+					ScrapedAt:      time.Now(),
+					PostedDate:     time.Now(),
+				}
+				if posted, err := time.Parse(time.RFC3339, posting.ReleasedDate); err == nil {
+					jobListing.PostedDate = posted
+				}
+				jobs = append(jobs, jobListing)
+			}
+		}
+
+		if len(response.Content) == 0 || offset+len(response.Content) >= response.TotalFound {
+			break
+		}
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,76 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestScrapeSingleSmartRecruitersCompany(t *testing.T) {
+	// 102 postings: a full first page and two on the second. Every tenth posting is relevant.
+	const total = 102
+	var offsets []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/companies/acme/postings" || r.URL.Query().Get("limit") != "100" {
+			http.NotFound(w, r)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		offsets = append(offsets, offset)
+
+		content := []map[string]interface{}{}
+		for i := offset; i < total && i < offset+100; i++ {
+			name := "Account Executive"
+			if i%10 == 0 {
+				name = "Platform Engineer"
+			}
+			content = append(content, map[string]interface{}{
+				"id":           fmt.Sprintf("74400%03d", i),
+				"name":         name,
+				"company":      map[string]string{"name": "Acme Inc."},
+				"releasedDate": "2026-09-01T12:00:00.000Z",
+				"location":     map[string]interface{}{"city": "Austin", "region": "TX", "country": "us", "remote": true},
+				"function":     map[string]string{"label": "Engineering"},
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"offset":     offset,
+			"limit":      100,
+			"totalFound": total,
+			"content":    content,
+		})
+	}))
+	defer server.Close()
+
+	as := NewAPIScraper(testConfig())
+	jobs, err := as.scrapeSingleSmartRecruitersCompany(context.Background(), server.URL+"/companies", "acme")
+	if err != nil {
+		t.Fatalf("scrapeSingleSmartRecruitersCompany: %v", err)
+	}
+
+	// Paging stops once totalFound is reached instead of asking for an empty third page
+	if len(offsets) != 2 || offsets[0] != 0 || offsets[1] != 100 {
+		t.Errorf("offsets requested = %v, want [0 100]", offsets)
+	}
+
+	if len(jobs) != 11 {
+		t.Fatalf("got %d jobs, want 11", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.ID != "smartrecruiters-acme-74400000" || first.Company != "Acme Inc." || first.Location != "Austin, TX, US" {
+		t.Errorf("first job = %+v", first)
+	}
+	if first.URL != "https://jobs.smartrecruiters.com/acme/74400000" || first.Department != "Engineering" || first.WorkplaceType != "remote" {
+		t.Errorf("first job URL = %q, department = %q, workplace = %q", first.URL, first.Department, first.WorkplaceType)
+	}
+	if last := jobs[10]; last.ID != "smartrecruiters-acme-74400100" {
+		t.Errorf("last job = %q, want the relevant posting of the second page", last.ID)
+	}
+}
@@ -0,0 +1,97 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// ScrapeSource scrapes a single source of any kind
+func (js *JobScraper) ScrapeSource(ctx context.Context, source config.Source) (*models.ScrapingResult, error) {
+	switch {
+	case source.Kind == config.SourceHTML && source.Site != nil:
+		return js.ScrapeSite(ctx, *source.Site)
+	case source.Kind == config.SourceAPI && source.APISite != nil:
+		return js.ScrapeAPISite(ctx, *source.APISite)
+	}
+
+	err := fmt.Errorf("source %s has unknown kind %q", source.Name(), source.Kind)
+	return &models.ScrapingResult{Site: source.Name(), Error: err}, err
+}
+
+// Run scrapes every source, several at once, and post-processes the jobs of all of them together.
+// Results keep the order of sources; sources not started before ctx is cancelled report ctx's error.
+func (js *JobScraper) Run(ctx context.Context, sources []config.Source) (*models.RunResult, error) {
+	start := time.Now()
+	results := make([]models.ScrapingResult, len(sources))
+
+	runPool(ctx, concurrency(js.cfg), len(sources), func(ctx context.Context, i int) {
+		result, err := js.ScrapeSource(ctx, sources[i])
+		if err != nil {
+			fmt.Printf("Error scraping %s: %v\n", sources[i].Name(), err)
+		}
+		results[i] = *result
+	})
+
+	err := fillCancelled(ctx, results, func(i int) string { return sources[i].Name() })
+
+	run := &models.RunResult{Results: results}
+	postProcess(run)
+	run.Duration = time.Since(start)
+	return run, err
+}
+
+// postProcess applies the rules shared by every source kind once all sources are done:
+// expired postings are dropped, and a job listed by several sources is kept only in the
+// first result that has it. run.Jobs collects what remains.
+func postProcess(run *models.RunResult) {
+	now := time.Now()
+	seen := make(map[string]bool)
+
+	for i := range run.Results {
+		result := &run.Results[i]
+
+		var jobs []models.JobListing
+		for _, job := range result.Jobs {
+			if !job.ValidThrough.IsZero() && job.ValidThrough.Before(now) {
+				run.Expired++
+				continue
+			}
+
+			key := jobKey(job)
+			if seen[key] {
+				run.Duplicates++
+				continue
+			}
+			seen[key] = true
+
+			if job.Source == "" {
+				job.Source = result.Site
+			}
+			jobs = append(jobs, job)
+		}
+
+		result.Jobs = jobs
+		run.Jobs = append(run.Jobs, jobs...)
+	}
+}
+
+// jobKey identifies a posting across sources: its URL and title when the URL is known,
+// otherwise its title, company and location. The title is part of the key because postings
+// without a link of their own (cards without an anchor, JSON-LD without a url) get the URL
+// of the page they were found on, which several postings share.
+func jobKey(job models.JobListing) string {
+	title := strings.ToLower(strings.TrimSpace(job.Title))
+	if job.URL != "" {
+		return strings.TrimSuffix(job.URL, "/") + "|" + title
+	}
+	return strings.Join([]string{
+		title,
+		strings.ToLower(strings.TrimSpace(job.Company)),
+		strings.ToLower(strings.TrimSpace(job.Location)),
+	}, "|")
+}
@@ -0,0 +1,42 @@
+package scraper
+
+import (
+	"context"
+	"testing"
+
+	"job-scraper/config"
+)
+
+func TestRunKeepsPostingsSharingThePageURL(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/careers": "jsonld_board/index.html",
+	})
+	js := NewJobScraper(testConfig())
+
+	// Neither posting has a url, so both carry the page URL
+	site := config.Site{Name: "Acme", URL: server.URL + "/careers", Selector: "li.job"}
+	mirror := site
+	mirror.Name = "Acme Mirror"
+
+	run, err := js.Run(context.Background(), []config.Source{config.HTMLSource(site), config.HTMLSource(mirror)})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if got := len(run.Results[0].Jobs); got != 2 {
+		t.Errorf("first source kept %d jobs, want 2: %+v", got, run.Results[0].Jobs)
+	}
+	for _, job := range run.Results[0].Jobs {
+		if job.URL != site.URL {
+			t.Errorf("job %q URL = %q, want the page URL", job.Title, job.URL)
+		}
+	}
+
+	// The same postings from a second source are still duplicates
+	if got := len(run.Results[1].Jobs); got != 0 {
+		t.Errorf("second source kept %d jobs, want 0", got)
+	}
+	if len(run.Jobs) != 2 || run.Duplicates != 2 {
+		t.Errorf("run has %d jobs and %d duplicates, want 2 and 2", len(run.Jobs), run.Duplicates)
+	}
+}
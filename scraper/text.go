@@ -0,0 +1,131 @@
+package scraper
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// blockElements are tags that start a new line when HTML is flattened to text
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "ul": true, "ol": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"tr": true, "table": true, "section": true, "article": true, "blockquote": true,
+}
+
+var (
+	relativeDateRegex = regexp.MustCompile(`(\d+)\+?\s+(minute|hour|day|week|month|year)s?\s+ago`)
+	postedPrefixRegex = regexp.MustCompile(`(?i)^(posted|published|reposted)(\s+on)?\s*:?\s+`)
+	inlineSpaceRegex  = regexp.MustCompile(`[ \t\f\r\x{00a0}]+`)
+	blankLinesRegex   = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// htmlToText converts an HTML fragment into readable plain text.
+// Block elements become line breaks, list items get a "- " prefix and
+// script/style contents are dropped.
+func htmlToText(fragment string) string {
+	if strings.TrimSpace(fragment) == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	tokenizer := xhtml.NewTokenizer(strings.NewReader(fragment))
+	skipDepth := 0
+
+	for {
+		switch tokenizer.Next() {
+		case xhtml.ErrorToken:
+			return cleanText(b.String())
+		case xhtml.TextToken:
+			if skipDepth == 0 {
+				b.Write(tokenizer.Text())
+			}
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			if tag == "script" || tag == "style" {
+				skipDepth++
+				continue
+			}
+			if blockElements[tag] {
+				b.WriteString("\n")
+			}
+			if tag == "li" {
+				b.WriteString("- ")
+			}
+		case xhtml.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			if (tag == "script" || tag == "style") && skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+			// List items only need the line break that starts the next item
+			if blockElements[tag] && tag != "li" {
+				b.WriteString("\n")
+			}
+		}
+	}
+}
+
+// escapedHTMLToText handles fields that carry entity-escaped markup such as "&lt;p&gt;",
+// falling back to htmlToText when the markup isn't escaped
+func escapedHTMLToText(fragment string) string {
+	if !strings.Contains(fragment, "<") && strings.Contains(fragment, "&lt;") {
+		fragment = html.UnescapeString(fragment)
+	}
+	return htmlToText(fragment)
+}
+
+// cleanText collapses runs of whitespace while keeping paragraph breaks
+func cleanText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(inlineSpaceRegex.ReplaceAllString(line, " "))
+	}
+	text = strings.Join(lines, "\n")
+	text = blankLinesRegex.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// parseRelativeDate converts text such as "Posted Today", "Posted Yesterday", "Posted 3 Days Ago"
+// or "2 weeks ago" into a date relative to now. "30+ Days Ago" is treated as exactly 30 days.
+func parseRelativeDate(text string, now time.Time) (time.Time, bool) {
+	text = strings.ToLower(strings.TrimSpace(postedPrefixRegex.ReplaceAllString(strings.TrimSpace(text), "")))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch text {
+	case "today", "just now", "just posted":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+
+	match := relativeDateRegex.FindStringSubmatch(text)
+	if match == nil {
+		return now, false
+	}
+	amount, err := strconv.Atoi(match[1])
+	if err != nil {
+		return now, false
+	}
+
+	switch match[2] {
+	case "minute":
+		return now.Add(-time.Duration(amount) * time.Minute), true
+	case "hour":
+		return now.Add(-time.Duration(amount) * time.Hour), true
+	case "day":
+		return today.AddDate(0, 0, -amount), true
+	case "week":
+		return today.AddDate(0, 0, -7*amount), true
+	case "month":
+		return today.AddDate(0, -amount, 0), true
+	default:
+		return today.AddDate(-amount, 0, 0), true
+	}
+}
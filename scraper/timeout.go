@@ -0,0 +1,56 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// ErrSiteTimeout is the error of results cut short by a deadline, either the site's
+// own timeout or the run's. Check for it with errors.Is.
+var ErrSiteTimeout = errors.New("site timed out")
+
+// withSiteTimeout bounds ctx by a site's timeout; zero leaves ctx unbounded
+func withSiteTimeout(ctx context.Context, timeout config.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(timeout))
+}
+
+// interruptedError returns why ctx stopped: ErrSiteTimeout for a deadline, otherwise ctx's error
+func interruptedError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrSiteTimeout
+	}
+	return ctx.Err()
+}
+
+// noteInterrupted records on ctx's request stats that some of the site's work was abandoned
+// because ctx stopped, for the scrapers that skip such work without returning an error
+func noteInterrupted(ctx context.Context) {
+	if stats, ok := ctx.Value(requestStatsKey{}).(*requestStats); ok {
+		atomic.StoreInt32(&stats.interrupted, 1)
+	}
+}
+
+// markInterrupted sets the error of a result whose site was stopped by ctx.
+// Jobs found before the interruption are kept. A site that finished all its work
+// just before ctx stopped, without an error or abandoned work, is left alone.
+func markInterrupted(ctx context.Context, result *models.ScrapingResult, err error) error {
+	if ctx.Err() == nil || result == nil {
+		return err
+	}
+
+	stats, _ := ctx.Value(requestStatsKey{}).(*requestStats)
+	if err == nil && (stats == nil || atomic.LoadInt32(&stats.interrupted) == 0) {
+		return nil
+	}
+
+	result.Error = interruptedError(ctx)
+	return result.Error
+}
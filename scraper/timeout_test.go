@@ -0,0 +1,137 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// newSlowBoard serves the first board page at once and holds the second one for delay
+func newSlowBoard(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	page1, err := os.ReadFile("testdata/html_board/page1.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "" {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page1)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSiteTimeoutKeepsPartialJobs(t *testing.T) {
+	server := newSlowBoard(t, 5*time.Second)
+	js := NewJobScraper(testConfig())
+
+	start := time.Now()
+	result, err := js.ScrapeSite(context.Background(), config.Site{
+		Name:       "Board",
+		URL:        server.URL + "/jobs",
+		Selector:   "li.job",
+		Pagination: config.HTMLPagination{NextSelector: "a.next", MaxPages: 3},
+		Timeout:    config.Duration(100 * time.Millisecond),
+	})
+
+	if !errors.Is(err, ErrSiteTimeout) || !errors.Is(result.Error, ErrSiteTimeout) {
+		t.Fatalf("err = %v, result error = %v; want ErrSiteTimeout", err, result.Error)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("site took %v despite its 100ms timeout", elapsed)
+	}
+	// The first page was parsed before the deadline
+	if len(result.Jobs) != 1 || result.Jobs[0].Title != "Senior DevOps Engineer" {
+		t.Errorf("jobs = %+v, want the job of the first page", result.Jobs)
+	}
+}
+
+func TestRunCancelFillsRemainingResults(t *testing.T) {
+	server := newSlowBoard(t, 5*time.Second)
+	cfg := testConfig()
+	cfg.Concurrency = 1
+	js := NewJobScraper(cfg)
+
+	var sources []config.Source
+	for _, name := range []string{"First", "Second", "Third"} {
+		sources = append(sources, config.HTMLSource(config.Site{
+			Name:       name,
+			URL:        server.URL + "/jobs?" + name,
+			Selector:   "li.job",
+			Pagination: config.HTMLPagination{NextSelector: "a.next", MaxPages: 3},
+		}))
+	}
+
+	// The first source is stuck on its second page when the run is cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	run, err := js.Run(ctx, sources)
+
+	if !errors.Is(err, ErrSiteTimeout) {
+		t.Fatalf("Run err = %v, want ErrSiteTimeout", err)
+	}
+	if len(run.Results) != len(sources) {
+		t.Fatalf("got %d results, want %d", len(run.Results), len(sources))
+	}
+	for i, result := range run.Results {
+		if result.Site != sources[i].Name() || !errors.Is(result.Error, ErrSiteTimeout) {
+			t.Errorf("result %d = {%q %v}, want {%q ErrSiteTimeout}", i, result.Site, result.Error, sources[i].Name())
+		}
+	}
+	if len(run.Results[0].Jobs) != 1 {
+		t.Errorf("first source kept %d jobs, want the 1 of its first page", len(run.Results[0].Jobs))
+	}
+}
+
+func TestMarkInterrupted(t *testing.T) {
+	failure := errors.New("API returned status 500")
+
+	tests := []struct {
+		name        string
+		err         error
+		interrupted bool // Work was abandoned without an error
+		want        error
+	}{
+		{"finished before the deadline", nil, false, nil},
+		{"abandoned work", nil, true, ErrSiteTimeout},
+		{"failed after the deadline", failure, false, ErrSiteTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := withRequestStats(context.Background())
+			ctx, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+			defer cancel()
+			if tt.interrupted {
+				noteInterrupted(ctx)
+			}
+
+			result := &models.ScrapingResult{Site: "Board", Error: tt.err}
+			err := markInterrupted(ctx, result, tt.err)
+			if !errors.Is(err, tt.want) || !errors.Is(result.Error, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("err = %v, result error = %v; want %v", err, result.Error, tt.want)
+			}
+		})
+	}
+
+	// Without an interruption the scrape error is returned as is
+	result := &models.ScrapingResult{Error: failure}
+	if err := markInterrupted(context.Background(), result, failure); err != failure || result.Error != failure {
+		t.Errorf("err = %v, result error = %v; want the scrape error", err, result.Error)
+	}
+}
@@ -0,0 +1,60 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+
+	"job-scraper/config"
+)
+
+// newTransport builds the transport every outbound request goes through: the robots.txt
+// check comes first, then the cache, so fresh entries cost no request. Retries wrap
+// per-host rate limiting and the global and per-host concurrency limits so every attempt is throttled.
+// Fixtures, when configured, stand in for the network underneath all of them.
+func newTransport(base http.RoundTripper, cfg config.ScraperConfig) http.RoundTripper {
+	base = newFixtureTransport(base, cfg.Fixtures)
+	transport := newRetryTransport(newRateLimitTransport(newLimitTransport(base, cfg), cfg), cfg)
+	return newRobotsTransport(newCacheTransport(transport, cfg.Cache), cfg.RespectRobotsTxt)
+}
+
+// contextTransport sends requests with the scrape's context. colly creates its requests
+// without one, so its collectors are wrapped with it to pass on cancellation and the
+// values other transports read, such as a site's rate limit.
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+// RoundTrip sends req with a context that carries the scrape's values and is cancelled
+// with either the scrape or the request's own context (colly's request timeout)
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(t.ctx, cancel)
+	release := func() {
+		stop()
+		cancel()
+	}
+
+	resp, err := t.base.RoundTrip(req.WithContext(scrapeContext{Context: ctx, values: t.ctx}))
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// scrapeContext is a request context that falls back to the scrape's context for values
+type scrapeContext struct {
+	context.Context
+	values context.Context
+}
+
+// Value looks key up on the request context first, then on the scrape's context
+func (c scrapeContext) Value(key interface{}) interface{} {
+	if value := c.Context.Value(key); value != nil {
+		return value
+	}
+	return c.values.Value(key)
+}
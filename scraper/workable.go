@@ -0,0 +1,155 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+// defaultWorkableBaseURL is Workable's public careers page API
+const defaultWorkableBaseURL = "https://apply.workable.com"
+
+// workableMaxPages caps the token pagination in case the API keeps returning a nextPage
+const workableMaxPages = 50
+
+// workableJob mirrors a single result of the Workable v3 jobs endpoint
+type workableJob struct {
+	ID        int    `json:"id"`
+	Shortcode string `json:"shortcode"`
+	Title     string `json:"title"`
+	Remote    bool   `json:"remote"`
+	Location  struct {
+		Country     string `json:"country"`
+		CountryCode string `json:"countryCode"`
+		City        string `json:"city"`
+		Region      string `json:"region"`
+	} `json:"location"`
+	State      string   `json:"state"`
+	Published  string   `json:"published"`
+	Type       string   `json:"type"` // "full", "part", "contract", "temporary", "other"
+	Department []string `json:"department"`
+	Workplace  string   `json:"workplace"` // "remote", "hybrid", "on_site"
+}
+
+func init() {
+	registerSource(sourceAdapter{
+		Type:     "workable_api",
+		Required: []string{"companies"},
+		Fetch:    (*APIScraper).scrapeWorkableAPI,
+	})
+}
+
+// scrapeWorkableAPI scrapes multiple Workable company accounts
+func (as *APIScraper) scrapeWorkableAPI(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
+	baseURL := apiSite.BaseURL
+	if baseURL == "" {
+		baseURL = defaultWorkableBaseURL
+	}
+
+	return as.scrapeCompanies(ctx, apiSite.Companies, func(ctx context.Context, company string) ([]models.JobListing, error) {
+		return as.scrapeSingleWorkableAccount(ctx, baseURL, company)
+	}), nil
+}
+
+// scrapeSingleWorkableAccount scrapes a single Workable account, following the nextPage token
+func (as *APIScraper) scrapeSingleWorkableAccount(ctx context.Context, baseURL, company string) ([]models.JobListing, error) {
+	var jobs []models.JobListing
+
+	baseURL = strings.TrimRight(baseURL, "/")
+	apiURL := fmt.Sprintf("%s/api/v3/accounts/%s/jobs", baseURL, url.PathEscape(company))
+
+	token := ""
+	for page := 0; page < workableMaxPages; page++ {
+		payload := map[string]interface{}{
+			"query":      "",
+			"location":   []string{},
+			"department": []string{},
+			"worktype":   []string{},
+			"remote":     []string{},
+		}
+		if token != "" {
+			payload["token"] = token
+		}
+
+		var response struct {
+			Total    int           `json:"total"`
+			Results  []workableJob `json:"results"`
+			NextPage string        `json:"nextPage"`
+		}
+		if err := as.postJSON(ctx, apiURL, payload, &response); err != nil {
+			return jobs, err
+		}
+
+		for _, job := range response.Results {
+			if job.State != "" && job.State != "published" {
+				continue
+			}
+
+			location := joinNonEmpty(", ", job.Location.City, job.Location.Region, job.Location.Country)
+
+			workplaceType := normalizeWorkplaceType(job.Workplace)
+			if job.Remote {
+				workplaceType = "remote"
+			}
+
+			if isRemoteJob(job.Title, company, location, workplaceType) && isRelevantRole(job.Title, "") {
+				jobListing := models.JobListing{
+					ID:             fmt.Sprintf("workable-%s-%s", company, job.Shortcode),
+					Title:          job.Title,
+					Company:        strings.Title(company), // Capitalize company name
+					Location:       location,
+					URL:            fmt.Sprintf("%s/%s/j/%s/", baseURL, url.PathEscape(company), job.Shortcode),
+					Source:         fmt.Sprintf("Workable - %s", strings.Title(company)),
+					Department:     strings.Join(job.Department, ", "),
+					EmploymentType: workableEmploymentType(job.Type),
+					WorkplaceType:  workplaceType,
+					ScrapedAt:      time.Now(),
+					PostedDate:     time.Now(),
+				}
+				if posted, err := time.Parse(time.RFC3339, job.Published); err == nil {
+					jobListing.PostedDate = posted
+				}
+				jobs = append(jobs, jobListing)
+			}
+		}
+
+		if response.NextPage == "" || len(response.Results) == 0 {
+			break
+		}
+		token = response.NextPage
+	}
+
+	return jobs, nil
+}
+
+// workableEmploymentType turns Workable's job type codes into readable labels
+func workableEmploymentType(jobType string) string {
+	switch jobType {
+	case "full":
+		return "Full-time"
+	case "part":
+		return "Part-time"
+	case "contract":
+		return "Contract"
+	case "temporary":
+		return "Temporary"
+	default:
+		return jobType
+	}
+}
+
+// joinNonEmpty joins the non-empty parts with sep
+func joinNonEmpty(sep string, parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
@@ -0,0 +1,74 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScrapeSingleWorkableAccount(t *testing.T) {
+	// The jobs endpoint is a POST whose body carries the nextPage token of the previous page
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/accounts/acme/jobs" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var payload struct {
+			Token string `json:"token"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		tokens = append(tokens, payload.Token)
+
+		file := "page1.json"
+		if payload.Token == "page-2-token" {
+			file = "page2.json"
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", "workable", file))
+		if err != nil {
+			t.Errorf("reading payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	as := NewAPIScraper(testConfig())
+	jobs, err := as.scrapeSingleWorkableAccount(context.Background(), server.URL, "acme")
+	if err != nil {
+		t.Fatalf("scrapeSingleWorkableAccount: %v", err)
+	}
+
+	// The second page has no nextPage, so exactly two requests are made
+	if len(tokens) != 2 || tokens[0] != "" || tokens[1] != "page-2-token" {
+		t.Errorf("tokens sent = %q, want [\"\" \"page-2-token\"]", tokens)
+	}
+
+	// The sales role isn't relevant and the closed job is skipped
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2: %+v", len(jobs), jobs)
+	}
+
+	devops := jobs[0]
+	if devops.ID != "workable-acme-A1B2C3" || devops.Location != "Lisbon, Portugal" || devops.Department != "Engineering, Platform" {
+		t.Errorf("first job = %+v", devops)
+	}
+	if devops.URL != server.URL+"/acme/j/A1B2C3/" || devops.EmploymentType != "Full-time" || devops.WorkplaceType != "remote" {
+		t.Errorf("first job URL = %q, employment type = %q, workplace = %q", devops.URL, devops.EmploymentType, devops.WorkplaceType)
+	}
+	if want := time.Date(2026, 9, 10, 8, 0, 0, 0, time.UTC); !devops.PostedDate.Equal(want) {
+		t.Errorf("posted date = %v, want %v", devops.PostedDate, want)
+	}
+
+	if sre := jobs[1]; sre.ID != "workable-acme-G7H8I9" || sre.Location != "Barcelona, Catalonia, Spain" || sre.EmploymentType != "Contract" {
+		t.Errorf("second job = %+v", sre)
+	}
+}
@@ -0,0 +1,234 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"job-scraper/config"
+	"job-scraper/models"
+)
+
+const (
+	// workdayPageSize is the largest page the cxs jobs endpoint accepts
+	workdayPageSize = 20
+
+	// workdayMaxPages guards against tenants that never report exhaustion
+	workdayMaxPages = 500
+)
+
+// workdayLocaleRegex matches the optional locale segment of career site URLs
+var workdayLocaleRegex = regexp.MustCompile(`^[a-z]{2}-[A-Z]{2}$`)
+
+// workdaySite identifies a single Workday career site, e.g.
+// https://nvidia.wd5.myworkdayjobs.com/en-US/NVIDIAExternalCareerSite
+type workdaySite struct {
+	Host   string // nvidia.wd5.myworkdayjobs.com
+	Tenant string // nvidia
+	Site   string // NVIDIAExternalCareerSite
+	Scheme string
+}
+
+// cxsURL returns the JSON API root of the career site
+func (ws workdaySite) cxsURL() string {
+	return fmt.Sprintf("%s://%s/wday/cxs/%s/%s", ws.Scheme, ws.Host, ws.Tenant, ws.Site)
+}
+
+// publicURL returns the candidate-facing URL of a posting
+func (ws workdaySite) publicURL(externalPath string) string {
+	return fmt.Sprintf("%s://%s/%s%s", ws.Scheme, ws.Host, ws.Site, externalPath)
+}
+
+// workdayPosting mirrors a single entry of the cxs jobs listing
+type workdayPosting struct {
+	Title         string   `json:"title"`
+	ExternalPath  string   `json:"externalPath"`
+	LocationsText string   `json:"locationsText"`
+	PostedOn      string   `json:"postedOn"`
+	BulletFields  []string `json:"bulletFields"`
+}
+
+// workdayPostingDetail mirrors the cxs detail response of a single posting
+type workdayPostingDetail struct {
+	JobPostingInfo struct {
+		ID                  string   `json:"id"`
+		Title               string   `json:"title"`
+		JobDescription      string   `json:"jobDescription"` // HTML
+		Location            string   `json:"location"`
+		AdditionalLocations []string `json:"additionalLocations"`
+		PostedOn            string   `json:"postedOn"`
+		StartDate           string   `json:"startDate"`
+		TimeType            string   `json:"timeType"`
+		RemoteType          string   `json:"remoteType"`
+		JobReqID            string   `json:"jobReqId"`
+		ExternalURL         string   `json:"externalUrl"`
+	} `json:"jobPostingInfo"`
+	HiringOrganization struct {
+		Name string `json:"name"`
+	} `json:"hiringOrganization"`
+}
+
+func init() {
+	registerSource(sourceAdapter{
+		Type: "workday",
+		Validate: func(apiSite config.APISite) error {
+			siteURLs := apiSite.Companies
+			if len(siteURLs) == 0 {
+				if apiSite.URL == "" {
+					return fmt.Errorf("workday requires career site URLs in companies or url")
+				}
+				siteURLs = []string{apiSite.URL}
+			}
+			for _, siteURL := range siteURLs {
+				if _, err := parseWorkdaySite(siteURL); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Fetch: (*APIScraper).scrapeWorkdayAPI,
+	})
+}
+
+// scrapeWorkdayAPI scrapes one or more Workday career sites.
+// Companies holds career site URLs; the site URL itself is used when it is empty.
+func (as *APIScraper) scrapeWorkdayAPI(ctx context.Context, apiSite config.APISite) ([]models.JobListing, error) {
+	siteURLs := apiSite.Companies
+	if len(siteURLs) == 0 {
+		siteURLs = []string{apiSite.URL}
+	}
+
+	return as.scrapeCompanies(ctx, siteURLs, func(ctx context.Context, siteURL string) ([]models.JobListing, error) {
+		return as.scrapeSingleWorkdaySite(ctx, siteURL, apiSite.Params["searchText"])
+	}), nil
+}
+
+// scrapeSingleWorkdaySite pages through a Workday career site and fetches the details of every relevant posting
+func (as *APIScraper) scrapeSingleWorkdaySite(ctx context.Context, siteURL, searchText string) ([]models.JobListing, error) {
+	var jobs []models.JobListing
+
+	site, err := parseWorkdaySite(siteURL)
+	if err != nil {
+		return jobs, err
+	}
+
+	total := 0
+	for page := 0; page < workdayMaxPages; page++ {
+		offset := page * workdayPageSize
+		payload := map[string]interface{}{
+			"appliedFacets": map[string]interface{}{},
+			"limit":         workdayPageSize,
+			"offset":        offset,
+			"searchText":    searchText,
+		}
+
+		var response struct {
+			Total       int              `json:"total"`
+			JobPostings []workdayPosting `json:"jobPostings"`
+		}
+		if err := as.postJSON(ctx, site.cxsURL()+"/jobs", payload, &response); err != nil {
+			return jobs, err
+		}
+
+		// Only the first page reliably reports the total, and some tenants report 0
+		if page == 0 {
+			total = response.Total
+		}
+
+		for _, posting := range response.JobPostings {
+			// The listing has no description, so every posting's detail is fetched before filtering
+			job := as.workdayJobListing(ctx, site, posting)
+			if isRemoteJob(job.Title, job.Company, job.Location, job.WorkplaceType+" "+job.Description) &&
+				isRelevantRole(job.Title, job.Description) {
+				jobs = append(jobs, job)
+			}
+		}
+
+		// A short page is the last one, whatever the total says
+		if len(response.JobPostings) < workdayPageSize || (total > 0 && offset+len(response.JobPostings) >= total) {
+			break
+		}
+	}
+
+	return jobs, nil
+}
+
+// workdayJobListing builds a JobListing from a listing entry, enriched with its detail page when available
+func (as *APIScraper) workdayJobListing(ctx context.Context, site workdaySite, posting workdayPosting) models.JobListing {
+	now := time.Now()
+	job := models.JobListing{
+		ID:        fmt.Sprintf("workday-%s-%s", site.Tenant, strings.TrimPrefix(posting.ExternalPath, "/job/")),
+		Title:     posting.Title,
+		Company:   strings.Title(site.Tenant), // Capitalize company name
+		Location:  posting.LocationsText,
+		URL:       site.publicURL(posting.ExternalPath),
+		Source:    fmt.Sprintf("Workday - %s", strings.Title(site.Tenant)),
+		ScrapedAt: now,
+	}
+	job.PostedDate, _ = parseRelativeDate(posting.PostedOn, now)
+
+	var detail workdayPostingDetail
+	if err := as.getJSON(ctx, site.cxsURL()+posting.ExternalPath, &detail); err != nil {
+		fmt.Printf("Error fetching Workday posting %s: %v\n", posting.ExternalPath, err)
+		return job
+	}
+
+	info := detail.JobPostingInfo
+	if info.JobReqID != "" {
+		job.ID = fmt.Sprintf("workday-%s-%s", site.Tenant, info.JobReqID)
+	}
+	if detail.HiringOrganization.Name != "" {
+		job.Company = detail.HiringOrganization.Name
+		job.Source = fmt.Sprintf("Workday - %s", detail.HiringOrganization.Name)
+	}
+	if info.Location != "" {
+		job.Location = strings.Join(append([]string{info.Location}, info.AdditionalLocations...), "; ")
+	}
+	if info.ExternalURL != "" {
+		job.URL = info.ExternalURL
+	}
+	job.Description = htmlToText(info.JobDescription)
+	job.EmploymentType = info.TimeType
+	job.WorkplaceType = normalizeWorkplaceType(info.RemoteType)
+
+	// startDate is an absolute date, which beats the relative postedOn text
+	if started, err := time.Parse("2006-01-02", info.StartDate); err == nil {
+		job.PostedDate = started
+	} else if posted, ok := parseRelativeDate(info.PostedOn, now); ok {
+		job.PostedDate = posted
+	}
+
+	return job
+}
+
+// parseWorkdaySite extracts host, tenant and site name from a career site URL
+func parseWorkdaySite(siteURL string) (workdaySite, error) {
+	u, err := url.Parse(siteURL)
+	if err != nil || u.Host == "" {
+		return workdaySite{}, fmt.Errorf("invalid Workday site URL %q", siteURL)
+	}
+
+	site := workdaySite{
+		Host:   u.Host,
+		Tenant: strings.Split(u.Hostname(), ".")[0],
+		Scheme: u.Scheme,
+	}
+	if site.Scheme == "" {
+		site.Scheme = "https"
+	}
+
+	for _, segment := range strings.Split(strings.Trim(u.Path, "/"), "/") {
+		if segment != "" && !workdayLocaleRegex.MatchString(segment) {
+			site.Site = segment
+			break
+		}
+	}
+	if site.Site == "" {
+		return workdaySite{}, fmt.Errorf("Workday site URL %q has no career site name", siteURL)
+	}
+
+	return site, nil
+}
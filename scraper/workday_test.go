@@ -0,0 +1,167 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScrapeSingleWorkdaySite(t *testing.T) {
+	// 25 postings: a full first page that reports a total of 0, then a short page.
+	// Every fifth posting is a platform role; posting 7 is relevant only through its description.
+	const count = 25
+	var offsets []int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/wday/cxs/acme/External/jobs", func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Offset int `json:"offset"`
+			Limit  int `json:"limit"`
+		}
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&payload) != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		offsets = append(offsets, payload.Offset)
+
+		postings := []map[string]interface{}{}
+		for i := payload.Offset; i < count && i < payload.Offset+payload.Limit; i++ {
+			title := "Account Executive"
+			if i%5 == 0 {
+				title = "Platform Engineer"
+			}
+			if i == 7 {
+				title = "Tooling Specialist"
+			}
+			postings = append(postings, map[string]interface{}{
+				"title":         title,
+				"externalPath":  fmt.Sprintf("/job/Remote/Job_R%03d", i),
+				"locationsText": "Remote - US",
+				"postedOn":      "Posted 3 Days Ago",
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"total": 0, "jobPostings": postings})
+	})
+	mux.HandleFunc("/wday/cxs/acme/External/job/Remote/", func(w http.ResponseWriter, r *http.Request) {
+		var i int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/wday/cxs/acme/External/job/Remote/Job_R"), "%d", &i)
+		if i == 20 {
+			http.NotFound(w, r)
+			return
+		}
+
+		description := "<p>Sell our product.</p>"
+		switch {
+		case i%5 == 0:
+			description = "<p>Build our internal platform.</p>"
+		case i == 7:
+			description = "<p>Own our Kubernetes and Terraform tooling.</p>"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jobPostingInfo": map[string]interface{}{
+				"title":               "ignored",
+				"jobDescription":      description,
+				"location":            "Remote - US",
+				"additionalLocations": []string{"Remote - Canada"},
+				"startDate":           "2026-09-14",
+				"timeType":            "Full time",
+				"remoteType":          "Remote",
+				"jobReqId":            fmt.Sprintf("R%03d", i),
+			},
+			"hiringOrganization": map[string]string{"name": "Acme Corp"},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Workday hosts carry the tenant name, so every host is sent to the test server
+	as := NewAPIScraper(testConfig())
+	as.client.Transport = dialServer(server)
+
+	jobs, err := as.scrapeSingleWorkdaySite(context.Background(), "http://acme.wd5.myworkdayjobs.com/en-US/External", "")
+	if err != nil {
+		t.Fatalf("scrapeSingleWorkdaySite: %v", err)
+	}
+
+	// The reported total of 0 doesn't stop paging; the short second page does
+	if len(offsets) != 2 || offsets[0] != 0 || offsets[1] != 20 {
+		t.Errorf("offsets requested = %v, want [0 20]", offsets)
+	}
+
+	var ids []string
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	want := []string{"workday-acme-R000", "workday-acme-R005", "workday-acme-R007", "workday-acme-R010", "workday-acme-R015", "workday-acme-Remote/Job_R020"}
+	if strings.Join(ids, " ") != strings.Join(want, " ") {
+		t.Fatalf("job IDs = %v, want %v", ids, want)
+	}
+
+	first := jobs[0]
+	if first.Company != "Acme Corp" || first.Location != "Remote - US; Remote - Canada" || first.WorkplaceType != "remote" {
+		t.Errorf("first job = %+v", first)
+	}
+	if first.Description != "Build our internal platform." || first.EmploymentType != "Full time" {
+		t.Errorf("first job description = %q, employment type = %q", first.Description, first.EmploymentType)
+	}
+	if want := time.Date(2026, 9, 14, 0, 0, 0, 0, time.UTC); !first.PostedDate.Equal(want) {
+		t.Errorf("posted date = %v, want the start date %v", first.PostedDate, want)
+	}
+	if first.URL != "http://acme.wd5.myworkdayjobs.com/External/job/Remote/Job_R000" {
+		t.Errorf("first job URL = %q", first.URL)
+	}
+
+	// A failed detail request keeps the listing fields
+	if last := jobs[5]; last.Company != "Acme" || last.Location != "Remote - US" || last.Description != "" {
+		t.Errorf("job without details = %+v", last)
+	}
+}
+
+func TestParseWorkdaySite(t *testing.T) {
+	site, err := parseWorkdaySite("https://nvidia.wd5.myworkdayjobs.com/en-US/NVIDIAExternalCareerSite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if site.Tenant != "nvidia" || site.Site != "NVIDIAExternalCareerSite" || site.Scheme != "https" {
+		t.Errorf("site = %+v", site)
+	}
+	if got := site.cxsURL(); got != "https://nvidia.wd5.myworkdayjobs.com/wday/cxs/nvidia/NVIDIAExternalCareerSite" {
+		t.Errorf("cxsURL = %q", got)
+	}
+
+	for _, siteURL := range []string{"not a url", "https://nvidia.wd5.myworkdayjobs.com/en-US/"} {
+		if _, err := parseWorkdaySite(siteURL); err == nil {
+			t.Errorf("parseWorkdaySite(%q) succeeded, want an error", siteURL)
+		}
+	}
+}
+
+func TestParseRelativeDate(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		text string
+		want time.Time
+		ok   bool
+	}{
+		{"Posted Today", today, true},
+		{"Posted Yesterday", today.AddDate(0, 0, -1), true},
+		{"Posted 3 Days Ago", today.AddDate(0, 0, -3), true},
+		{"Posted 30+ Days Ago", today.AddDate(0, 0, -30), true},
+		{"2 weeks ago", today.AddDate(0, 0, -14), true},
+		{"5 hours ago", now.Add(-5 * time.Hour), true},
+		{"1 month ago", today.AddDate(0, -1, 0), true},
+		{"sometime", now, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRelativeDate(tt.text, now)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("parseRelativeDate(%q) = %v, %v; want %v, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}